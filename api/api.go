@@ -0,0 +1,63 @@
+package api
+
+import (
+	"crypto/subtle"
+	"dsacli/practice"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// NewHandler returns the HTTP handler for the JSON API. Every endpoint except the
+// OpenAPI document requires an "Authorization: Bearer <token>" header.
+func NewHandler(service *practice.Service, token string) http.Handler {
+	h := handlers{service: service}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /today", h.getToday)
+	mux.HandleFunc("GET /due", h.getDue)
+	mux.HandleFunc("GET /questions", h.listQuestions)
+	mux.HandleFunc("GET /questions/{id}", h.getQuestion)
+	mux.HandleFunc("GET /questions/{id}/statement", h.getStatement)
+	mux.HandleFunc("POST /questions/{id}/attempts", h.createAttempt)
+	mux.HandleFunc("GET /stats", h.getStats)
+
+	// Fallbacks so that unsupported methods and unknown paths also get an error object
+	for _, path := range []string{"/today", "/due", "/questions", "/questions/{id}", "/questions/{id}/statement", "/questions/{id}/attempts", "/stats"} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, fmt.Sprintf("method %s is not allowed", r.Method))
+		})
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		notFound(w, "no such endpoint")
+	})
+
+	return withOpenAPI(withAuth(mux, token))
+}
+
+func withAuth(next http.Handler, token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			writeError(w, http.StatusUnauthorized, CodeUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withOpenAPI serves the OpenAPI document without authentication so tools can discover the API
+func withOpenAPI(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/openapi.yaml" {
+			w.Header().Set("Content-Type", "application/yaml")
+			_, _ = w.Write(openAPISpec)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,358 @@
+package api
+
+import (
+	"bytes"
+	"dsacli/config"
+	"dsacli/db"
+	"dsacli/practice"
+	"dsacli/types"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testToken = "test-token"
+
+func newTestServer(t *testing.T) (*httptest.Server, db.Database) {
+	t.Helper()
+
+	cfg := config.NewConfig(filepath.Join(t.TempDir(), "test.db"))
+	database, err := db.NewSQLDatabase(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	questions := []types.Question{
+		{Name: "Two Sum", URL: "https://leetcode.com/problems/two-sum", Difficulty: "easy", EasinessFactor: 2.5},
+		{Name: "Valid Anagram", URL: "https://leetcode.com/problems/valid-anagram", Difficulty: "easy", EasinessFactor: 2.5},
+	}
+	if err := database.InsertQuestions(questions); err != nil {
+		t.Fatalf("Failed to insert questions: %v", err)
+	}
+
+	server := httptest.NewServer(NewHandler(practice.NewService(database, cfg), testToken))
+	t.Cleanup(server.Close)
+	return server, database
+}
+
+func doRequest(t *testing.T, method, url, token string, body any) *http.Response {
+	t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatalf("Failed to encode body: %v", err)
+		}
+	}
+
+	req, err := http.NewRequest(method, url, &payload)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func decodeError(t *testing.T, resp *http.Response) ErrorBody {
+	t.Helper()
+
+	var errResp ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	return errResp.Error
+}
+
+func TestAuthentication(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	tests := []struct {
+		name           string
+		path           string
+		token          string
+		expectedStatus int
+	}{
+		{"Missing token", "/today", "", http.StatusUnauthorized},
+		{"Wrong token", "/today", "wrong", http.StatusUnauthorized},
+		{"Valid token", "/today", testToken, http.StatusOK},
+		{"OpenAPI document is public", "/openapi.yaml", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, http.MethodGet, server.URL+tt.path, tt.token, nil)
+			if resp.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if resp.StatusCode == http.StatusUnauthorized {
+				if code := decodeError(t, resp).Code; code != CodeUnauthorized {
+					t.Errorf("Expected error code %q, got %q", CodeUnauthorized, code)
+				}
+			}
+		})
+	}
+
+	t.Run("Unsupported method", func(t *testing.T) {
+		resp := doRequest(t, http.MethodDelete, server.URL+"/questions/1/attempts", testToken, nil)
+		if resp.StatusCode != http.StatusMethodNotAllowed {
+			t.Fatalf("Expected status %d, got %d", http.StatusMethodNotAllowed, resp.StatusCode)
+		}
+		if code := decodeError(t, resp).Code; code != CodeMethodNotAllowed {
+			t.Errorf("Expected error code %q, got %q", CodeMethodNotAllowed, code)
+		}
+	})
+
+	t.Run("No cross-origin access", func(t *testing.T) {
+		resp := doRequest(t, http.MethodOptions, server.URL+"/today", "", nil)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, resp.StatusCode)
+		}
+		if origin := resp.Header.Get("Access-Control-Allow-Origin"); origin != "" {
+			t.Errorf("Expected no CORS headers, got Access-Control-Allow-Origin %q", origin)
+		}
+	})
+}
+
+func TestGetToday(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	resp := doRequest(t, http.MethodGet, server.URL+"/today", testToken, nil)
+	var plan []types.TodayQuestionWithStatus
+	if err := json.NewDecoder(resp.Body).Decode(&plan); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(plan) != 2 {
+		t.Errorf("Expected 2 questions for today, got %d", len(plan))
+	}
+}
+
+func TestGetTodayConcurrently(t *testing.T) {
+	server, database := newTestServer(t)
+
+	const requests = 8
+	var wg sync.WaitGroup
+	statuses := make(chan int, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/today", nil)
+			if err != nil {
+				statuses <- 0
+				return
+			}
+			req.Header.Set("Authorization", "Bearer "+testToken)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				statuses <- 0
+				return
+			}
+			resp.Body.Close()
+			statuses <- resp.StatusCode
+		}()
+	}
+	wg.Wait()
+	close(statuses)
+	for status := range statuses {
+		if status != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, status)
+		}
+	}
+
+	_, entries, err := database.GetTodayQuestions(config.DefaultSettings().Calendar().Date(time.Now()))
+	if err != nil {
+		t.Fatalf("GetTodayQuestions() unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Expected a single plan of 2 questions, got %d entries", len(entries))
+	}
+}
+
+func TestCreateAttempt(t *testing.T) {
+	server, database := newTestServer(t)
+
+	tests := []struct {
+		name           string
+		path           string
+		body           any
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "Valid attempt",
+			path:           "/questions/1/attempts",
+			body:           map[string]int{"hints_needed": 0, "time_taken": 20, "optimal_solution": 5, "any_bugs": 5},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Out of range rating",
+			path:           "/questions/1/attempts",
+			body:           map[string]int{"hints_needed": 0, "time_taken": 20, "optimal_solution": 9, "any_bugs": 5},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeBadRequest,
+		},
+		{
+			name:           "Unknown field",
+			path:           "/questions/1/attempts",
+			body:           map[string]int{"hints": 0},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeBadRequest,
+		},
+		{
+			name:           "Unknown question",
+			path:           "/questions/999/attempts",
+			body:           map[string]int{"hints_needed": 0, "time_taken": 20, "optimal_solution": 5, "any_bugs": 5},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   CodeNotFound,
+		},
+		{
+			name:           "Invalid id",
+			path:           "/questions/abc/attempts",
+			body:           map[string]int{},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, http.MethodPost, server.URL+tt.path, testToken, tt.body)
+			if resp.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if tt.expectedCode != "" {
+				if code := decodeError(t, resp).Code; code != tt.expectedCode {
+					t.Errorf("Expected error code %q, got %q", tt.expectedCode, code)
+				}
+			}
+		})
+	}
+
+	question, err := database.FindQuestionByID(1)
+	if err != nil {
+		t.Fatalf("Failed to load question: %v", err)
+	}
+	if !question.Attempted || question.AttemptCount != 1 {
+		t.Errorf("Expected question to be attempted once, got attempted=%v count=%d", question.Attempted, question.AttemptCount)
+	}
+
+	attempts, err := database.GetAttempts()
+	if err != nil {
+		t.Fatalf("Failed to load attempts: %v", err)
+	}
+	if len(attempts) != 1 {
+		t.Errorf("Expected 1 recorded attempt, got %d", len(attempts))
+	}
+}
+
+func TestListQuestionsByURL(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	resp := doRequest(t, http.MethodGet, server.URL+"/questions?url=https://leetcode.com/problems/two-sum/", testToken, nil)
+	var questions []types.Question
+	if err := json.NewDecoder(resp.Body).Decode(&questions); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(questions) != 1 || questions[0].Name != "Two Sum" {
+		t.Errorf("Expected to find Two Sum by URL, got %v", questions)
+	}
+}
+
+func TestListQuestionsByTag(t *testing.T) {
+	server, database := newTestServer(t)
+	for id, tags := range map[uint][]string{1: {"hash_map"}, 2: {"hashxmap"}} {
+		question, err := database.FindQuestionByID(id)
+		if err != nil {
+			t.Fatalf("Failed to load question: %v", err)
+		}
+		question.Tags = tags
+		if err := database.UpdateQuestion(question); err != nil {
+			t.Fatalf("Failed to tag question: %v", err)
+		}
+	}
+
+	tests := []struct {
+		tag      string
+		expected []string
+	}{
+		{"hash_map", []string{"Two Sum"}},
+		{"hashxmap", []string{"Valid Anagram"}},
+		{"%", nil},
+		{"hash%", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			resp := doRequest(t, http.MethodGet, server.URL+"/questions?tag="+url.QueryEscape(tt.tag), testToken, nil)
+			var questions []types.Question
+			if err := json.NewDecoder(resp.Body).Decode(&questions); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			var names []string
+			for _, q := range questions {
+				names = append(names, q.Name)
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("GET /questions?tag=%s = %v, want %v", tt.tag, names, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetStats(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	doRequest(t, http.MethodPost, server.URL+"/questions/2/attempts", testToken,
+		map[string]int{"hints_needed": 1, "time_taken": 40, "optimal_solution": 3, "any_bugs": 3})
+
+	resp := doRequest(t, http.MethodGet, server.URL+"/stats", testToken, nil)
+	var stats practice.Status
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if stats.TotalQuestions != 2 || stats.Attempted != 1 || stats.TotalAttempts != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if stats.Streak != 1 {
+		t.Errorf("Expected streak of 1, got %d", stats.Streak)
+	}
+	if len(stats.PScoreTrend) != 1 {
+		t.Errorf("Expected 1 trend point, got %d", len(stats.PScoreTrend))
+	}
+}
+
+func TestGetStatement(t *testing.T) {
+	server, database := newTestServer(t)
+	content := types.QuestionContent{QuestionID: 1, Statement: "Find two numbers adding up to target.", Constraints: "- 2 <= n"}
+	if err := database.SaveQuestionContents([]types.QuestionContent{content}); err != nil {
+		t.Fatalf("Failed to save statement: %v", err)
+	}
+
+	resp := doRequest(t, http.MethodGet, server.URL+"/questions/1/statement", testToken, nil)
+	var got types.QuestionContent
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || got != content {
+		t.Errorf("GET /questions/1/statement = %d %+v, want 200 %+v", resp.StatusCode, got, content)
+	}
+
+	resp = doRequest(t, http.MethodGet, server.URL+"/questions/2/statement", testToken, nil)
+	if resp.StatusCode != http.StatusNotFound || decodeError(t, resp).Code != CodeNotFound {
+		t.Errorf("GET /questions/2/statement = %d, want 404 without a statement", resp.StatusCode)
+	}
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"dsacli/common"
+	"dsacli/db"
+	"dsacli/practice"
+	"dsacli/types"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+type handlers struct {
+	service *practice.Service
+}
+
+func (h handlers) getToday(w http.ResponseWriter, r *http.Request) {
+	plan, err := h.service.PlanToday(false)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	if plan.Questions == nil {
+		plan.Questions = []types.TodayQuestionWithStatus{}
+	}
+	writeJSON(w, http.StatusOK, plan.Questions)
+}
+
+func (h handlers) getDue(w http.ResponseWriter, r *http.Request) {
+	questions, err := h.service.Due()
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	writeQuestions(w, questions)
+}
+
+func (h handlers) listQuestions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	questions, err := h.service.Questions(db.QuestionFilter{
+		Difficulties: common.SplitCSV(query.Get("difficulty")),
+		Tag:          query.Get("tag"),
+		URL:          query.Get("url"),
+		Sort:         query.Get("sort"),
+		Due:          query.Get("due") == "true",
+	})
+	if err != nil {
+		badRequest(w, err.Error())
+		return
+	}
+	writeQuestions(w, questions)
+}
+
+func writeQuestions(w http.ResponseWriter, questions []types.Question) {
+	if questions == nil {
+		questions = []types.Question{}
+	}
+	writeJSON(w, http.StatusOK, questions)
+}
+
+func (h handlers) getQuestion(w http.ResponseWriter, r *http.Request) {
+	question, ok := h.findQuestion(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, question)
+}
+
+func (h handlers) getStatement(w http.ResponseWriter, r *http.Request) {
+	question, ok := h.findQuestion(w, r)
+	if !ok {
+		return
+	}
+	content, err := h.service.Statement(question.ID)
+	if errors.Is(err, practice.ErrNoStatement) {
+		notFound(w, fmt.Sprintf("no statement stored for question %d", question.ID))
+		return
+	}
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, content)
+}
+
+func (h handlers) createAttempt(w http.ResponseWriter, r *http.Request) {
+	question, ok := h.findQuestion(w, r)
+	if !ok {
+		return
+	}
+
+	var feedback practice.Feedback
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&feedback); err != nil {
+		badRequest(w, fmt.Sprintf("invalid feedback: %v", err))
+		return
+	}
+	if err := feedback.Validate(); err != nil {
+		badRequest(w, err.Error())
+		return
+	}
+
+	result, err := h.service.RecordAttempt(question.ID, feedback)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, result.Question)
+}
+
+func (h handlers) getStats(w http.ResponseWriter, r *http.Request) {
+	status, err := h.service.Status()
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// findQuestion loads the question from the {id} path parameter, writing an error response if it can't
+func (h handlers) findQuestion(w http.ResponseWriter, r *http.Request) (types.Question, bool) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		badRequest(w, fmt.Sprintf("invalid question id %q", r.PathValue("id")))
+		return types.Question{}, false
+	}
+
+	question, err := h.service.Question(uint(id))
+	if errors.Is(err, practice.ErrQuestionNotFound) {
+		notFound(w, fmt.Sprintf("question with ID %d not found", id))
+		return types.Question{}, false
+	}
+	if err != nil {
+		internalError(w, err)
+		return types.Question{}, false
+	}
+	return question, true
+}
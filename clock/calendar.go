@@ -0,0 +1,53 @@
+package clock
+
+import (
+	"fmt"
+	"time"
+)
+
+// DateLayout is how practice days are stored
+const DateLayout = "2006-01-02"
+
+// Calendar maps instants to practice days. A practice day starts at StartHour in Location,
+// so with a StartHour of 4 a session at 1am still counts towards the previous day.
+type Calendar struct {
+	Location  *time.Location
+	StartHour int
+}
+
+// Day returns midnight of the practice day t falls in
+func (c Calendar) Day(t time.Time) time.Time {
+	loc := c.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	shifted := t.In(loc).Add(-time.Duration(c.StartHour) * time.Hour)
+	return time.Date(shifted.Year(), shifted.Month(), shifted.Day(), 0, 0, 0, 0, loc)
+}
+
+// Date returns the practice day t falls in formatted as YYYY-MM-DD
+func (c Calendar) Date(t time.Time) string {
+	return c.Day(t).Format(DateLayout)
+}
+
+// ParseDate reads a YYYY-MM-DD date
+func ParseDate(value string) (time.Time, error) {
+	t, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: expected YYYY-MM-DD", value)
+	}
+	return t, nil
+}
+
+// DaysBetween returns the number of days from one YYYY-MM-DD date to another
+func DaysBetween(from, to string) (int, error) {
+	start, err := ParseDate(from)
+	if err != nil {
+		return 0, err
+	}
+	end, err := ParseDate(to)
+	if err != nil {
+		return 0, err
+	}
+	return int(end.Sub(start).Hours() / 24), nil
+}
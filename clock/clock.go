@@ -0,0 +1,102 @@
+// Package clock lets the current time be injected so day boundaries can be tested and simulated
+package clock
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Clock tells the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// System is the real wall clock
+type System struct{}
+
+func (System) Now() time.Time {
+	return time.Now()
+}
+
+// Fixed is a clock that is stopped at the given time
+type Fixed time.Time
+
+func (f Fixed) Now() time.Time {
+	return time.Time(f)
+}
+
+// Adjustable follows the wall clock until it's moved to another time with Set, after which
+// it keeps ticking in real time from there
+type Adjustable struct {
+	mu     sync.RWMutex
+	offset time.Duration
+}
+
+// NewAdjustable returns a clock following the wall clock
+func NewAdjustable() *Adjustable {
+	return &Adjustable{}
+}
+
+func (a *Adjustable) Now() time.Time {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return time.Now().Add(a.offset)
+}
+
+// Set moves the clock so that it currently reads t
+func (a *Adjustable) Set(t time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.offset = time.Until(t)
+}
+
+// Advance moves the clock forward by d
+func (a *Adjustable) Advance(d time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.offset += d
+}
+
+// Manual only moves when it's set or advanced, used where runs must be reproducible
+type Manual struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+// NewManual returns a clock stopped at t
+func NewManual(t time.Time) *Manual {
+	return &Manual{now: t}
+}
+
+func (m *Manual) Now() time.Time {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.now
+}
+
+// Set moves the clock to t
+func (m *Manual) Set(t time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = t
+}
+
+// Advance moves the clock forward by d
+func (m *Manual) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = m.now.Add(d)
+}
+
+var layouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"}
+
+// Parse reads a time given as RFC3339 or a local date with an optional time of day
+func Parse(value string) (time.Time, error) {
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: expected YYYY-MM-DD, YYYY-MM-DDTHH:MM or RFC3339", value)
+}
@@ -0,0 +1,113 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Time
+		wantErr  bool
+	}{
+		{name: "Date only", value: "2024-03-10", expected: time.Date(2024, 3, 10, 0, 0, 0, 0, time.Local)},
+		{name: "Date and time", value: "2024-03-10T21:30", expected: time.Date(2024, 3, 10, 21, 30, 0, 0, time.Local)},
+		{name: "RFC3339", value: "2024-03-10T21:30:00Z", expected: time.Date(2024, 3, 10, 21, 30, 0, 0, time.UTC)},
+		{name: "Invalid", value: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Parse(%q) expected error", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.value, err)
+			}
+			if !got.Equal(tt.expected) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAdjustable(t *testing.T) {
+	c := NewAdjustable()
+	target := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	c.Set(target)
+	if diff := c.Now().Sub(target); diff < 0 || diff > time.Second {
+		t.Errorf("Now() after Set = %v, want about %v", c.Now(), target)
+	}
+
+	c.Advance(24 * time.Hour)
+	if diff := c.Now().Sub(target.Add(24 * time.Hour)); diff < 0 || diff > time.Second {
+		t.Errorf("Now() after Advance = %v, want about a day after %v", c.Now(), target)
+	}
+}
+
+func TestManual(t *testing.T) {
+	target := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	c := NewManual(target)
+
+	if !c.Now().Equal(target) {
+		t.Errorf("Now() = %v, want %v", c.Now(), target)
+	}
+	c.Advance(time.Hour)
+	if !c.Now().Equal(target.Add(time.Hour)) {
+		t.Errorf("Now() after Advance = %v, want %v", c.Now(), target.Add(time.Hour))
+	}
+}
+
+func TestCalendarDate(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		calendar Calendar
+		at       time.Time
+		expected string
+	}{
+		{
+			name:     "Midnight boundary",
+			calendar: Calendar{Location: time.UTC},
+			at:       time.Date(2024, 3, 10, 23, 50, 0, 0, time.UTC),
+			expected: "2024-03-10",
+		},
+		{
+			name:     "Before day start counts as previous day",
+			calendar: Calendar{Location: time.UTC, StartHour: 4},
+			at:       time.Date(2024, 3, 11, 0, 10, 0, 0, time.UTC),
+			expected: "2024-03-10",
+		},
+		{
+			name:     "After day start",
+			calendar: Calendar{Location: time.UTC, StartHour: 4},
+			at:       time.Date(2024, 3, 11, 4, 0, 0, 0, time.UTC),
+			expected: "2024-03-11",
+		},
+		{
+			name:     "Converted to the configured time zone",
+			calendar: Calendar{Location: berlin},
+			at:       time.Date(2024, 3, 10, 23, 30, 0, 0, time.UTC),
+			expected: "2024-03-11",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.calendar.Date(tt.at); got != tt.expected {
+				t.Errorf("Date(%v) = %s, want %s", tt.at, got, tt.expected)
+			}
+		})
+	}
+}
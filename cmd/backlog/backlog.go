@@ -0,0 +1,77 @@
+package backlog
+
+import (
+	"dsacli/practice"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand(service *practice.Service) *cobra.Command {
+	Command := &cobra.Command{
+		Use:   "backlog",
+		Short: "Show unfinished questions from missed days",
+		Long: `Show the unfinished questions of earlier plans. Up to carry-over-cap of them (see "dsacli config")
+are rolled into each new day's plan; the rest stay here until they are carried over or cleared.`,
+		Args: cobra.NoArgs,
+		Run:  backlogCmd(service),
+	}
+
+	Command.AddCommand(&cobra.Command{
+		Use:   "clear [date]",
+		Short: "Clear the backlog, or only the plan of one day (YYYY-MM-DD)",
+		Args:  cobra.MaximumNArgs(1),
+		Run:   clearCmd(service),
+	})
+
+	return Command
+}
+
+func backlogCmd(service *practice.Service) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executeBacklog(service); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+func executeBacklog(service *practice.Service) error {
+	backlog, err := service.Backlog()
+	if err != nil {
+		return err
+	}
+
+	if len(backlog) == 0 {
+		color.Green("No missed questions, you're all caught up!")
+		return nil
+	}
+
+	color.Yellow("Missed questions (%d):", len(backlog))
+	date := ""
+	for _, missed := range backlog {
+		if missed.Date != date {
+			date = missed.Date
+			color.Cyan("%s", date)
+		}
+		fmt.Printf("    - %s (ID: %d, %s)\n", missed.Question.Name, missed.Question.ID, missed.Question.Difficulty)
+	}
+	return nil
+}
+
+func clearCmd(service *practice.Service) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		date := ""
+		if len(args) == 1 {
+			date = args[0]
+		}
+
+		cleared, err := service.ClearBacklog(date)
+		if err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+		color.Green("Cleared %d missed question(s) from the backlog", cleared)
+	}
+}
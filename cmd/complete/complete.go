@@ -1,189 +1,366 @@
 package complete
 
 import (
+	"dsacli/cmd/leeches"
 	"dsacli/common"
-	"dsacli/db"
+	"dsacli/practice"
 	"dsacli/types"
 	"fmt"
-	"time"
+	"strconv"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
-const (
-	DateFormat = "2006-01-02T15:04:05"
-
-	// Rating bounds for user feedback
-	MinRating = 1
-	MaxRating = 5
-
-	UnsolvedTimeValue = -1
+// Feedback flags, every one that isn't given is prompted for
+var (
+	hints       int
+	minutes     int
+	optimality  int
+	bugs        int
+	confidence  int
+	sawSolution bool
+	failure     string
+	approach    string
+
+	queueSimilar bool
 )
 
-type CompletionFeedback struct {
-	HintsNeeded     int
-	TimeTaken       int
-	OptimalSolution int
-	AnyBugs         int
-}
+// feedbackFlags are the names of the feedback flags, giving any of them makes the command non-interactive
+var feedbackFlags = []string{"hints", "time", "optimality", "bugs", "confidence", "saw-solution", "failure", "approach"}
 
-func GetCommand(db db.Database) *cobra.Command {
-	return &cobra.Command{
-		Use:   "complete",
+// maxOfferedSimilar is the number of similar questions offered after a failed recall
+const maxOfferedSimilar = 3
+
+func GetCommand(service *practice.Service) *cobra.Command {
+	Command := &cobra.Command{
+		Use:   "complete [question_id]",
 		Short: "Mark a question as complete",
-		Long:  `Mark a question as complete and provide feedback to update its SR score.`,
-		Run:   completeCmd(db),
+		Long: `Mark a question as complete and provide feedback to update its SR score.
+Without a question ID you pick one of today's open questions. Feedback given with flags isn't
+prompted for, e.g. "dsacli complete 12 --hints 0 --time 25 --optimality 5 --bugs 4".`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  completeCmd(service),
 	}
+
+	Command.Flags().IntVar(&hints, "hints", 0, fmt.Sprintf("Number of hints used (0-%d)", practice.MaxHints))
+	Command.Flags().IntVar(&minutes, "time", 0, fmt.Sprintf("Minutes taken (1-%d), %d if you couldn't solve it", practice.MaxMinutes, practice.UnsolvedTimeValue))
+	Command.Flags().IntVar(&optimality, "optimality", 0, "Was the solution optimal? (1=not optimal, 5=very optimal)")
+	Command.Flags().IntVar(&bugs, "bugs", 0, "Were there any bugs? (1=many bugs, 5=no bugs)")
+	Command.Flags().IntVar(&confidence, "confidence", practice.NoConfidence, "How confident you are in the solution (1=guessing, 5=certain)")
+	Command.Flags().BoolVar(&sawSolution, "saw-solution", false, "You looked at the solution")
+	Command.Flags().StringVar(&failure, "failure", "", "What went wrong: "+strings.Join(types.FailureCategories, ", "))
+	Command.Flags().StringVar(&approach, "approach", "", "Approach used, e.g. two pointers")
+	Command.Flags().BoolVar(&queueSimilar, "queue-similar", false, "After a low p-score, add the most similar question to today's plan without asking")
+
+	return Command
 }
 
-func completeCmd(db db.Database) func(cmd *cobra.Command, args []string) {
+func completeCmd(service *practice.Service) func(cmd *cobra.Command, args []string) {
 	return func(cmd *cobra.Command, args []string) {
-		if err := executeComplete(db); err != nil {
+		if err := executeComplete(service, cmd.Flags(), args); err != nil {
 			color.Red("Error: %v", err)
 			return
 		}
 	}
 }
 
-// executeComplete contains the main business logic for completing questions
-func executeComplete(db db.Database) error {
-	// Get today's questions
-	todaysQuestions, todaysTrack, err := db.GetTodayQuestions()
+// executeComplete records the feedback for the given question, or for the one of today's
+// questions the user selects
+func executeComplete(service *practice.Service, flags *pflag.FlagSet, args []string) error {
+	var questionToUpdate types.Question
+	if len(args) == 1 {
+		id, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid question id %q", args[0])
+		}
+		if questionToUpdate, err = service.Question(uint(id)); err != nil {
+			return err
+		}
+	} else {
+		question, ok, err := selectOpenQuestion(service)
+		if err != nil || !ok {
+			return err
+		}
+		questionToUpdate = question
+	}
+
+	// Display question info
+	color.Cyan("You are about to update the question: %s (ID: %d)", questionToUpdate.Name, questionToUpdate.ID)
+
+	// Collect user feedback
+	feedback, err := collectFeedback(flags)
 	if err != nil {
-		return fmt.Errorf("loading today's questions: %w", err)
+		return fmt.Errorf("collecting feedback: %w", err)
 	}
 
-	if len(todaysQuestions) == 0 {
-		color.Red("No questions found for today. Start by running 'dsacli today' to get today's questions.")
-		return nil // This is not an error, just a message to the user
+	// Update question with feedback, save it and mark it as completed for today
+	result, err := service.RecordAttempt(questionToUpdate.ID, feedback)
+	if err != nil {
+		return err
 	}
 
-	// If all questions for today are already completed, exit early
-	uncompletedQns := make([]types.Question, 0)
-	for _, tq := range todaysTrack {
-		if !tq.Completed {
-			qn, found := common.FindInSlice(todaysQuestions, func(question types.Question) bool {
-				return question.ID == tq.QuestionID
-			})
-			if found {
-				uncompletedQns = append(uncompletedQns, qn)
-			}
+	printResult(result.Question)
+
+	// Prompting would block scripted completions, which pass their feedback as flags
+	interactive := !feedbackGiven(flags)
+	if result.Leech != nil {
+		if !interactive {
+			color.Red("\n'%s' is now a leech, see 'dsacli leeches' for what to do about it.", result.Question.Name)
+		} else if err := leeches.Remediate(service, *result.Leech); err != nil {
+			return err
 		}
 	}
-
-	if len(uncompletedQns) == 0 {
-		color.Yellow("All questions for today are already completed. No further action needed.")
-		return nil // No error, just a message to the user
+	if result.FailedRecall() && (interactive || queueSimilar) {
+		return offerSimilar(service, result.Question)
 	}
+	return nil
+}
 
-	color.Cyan("You have completed %d out of %d questions for today.", len(todaysTrack)-len(uncompletedQns), len(todaysTrack))
-
-	// Let user select a question
-	questionID, err := selectQuestion(uncompletedQns)
+// offerSimilar queues a question practicing the same pattern into today's plan after a failed
+// recall: the most similar one with --queue-similar, the one picked by the user otherwise
+func offerSimilar(service *practice.Service, question types.Question) error {
+	plan, err := service.Today()
+	if err != nil {
+		return err
+	}
+	planned := make(map[uint]bool)
+	for _, q := range plan.Questions {
+		planned[q.Question.ID] = true
+	}
+	similar, err := service.Similar(question.ID, practice.DefaultSimilarLimit)
 	if err != nil {
-		return fmt.Errorf("selecting question: %w", err)
+		return err
+	}
+	var offered []practice.Similar
+	for _, s := range similar {
+		if !planned[s.Question.ID] && len(offered) < maxOfferedSimilar {
+			offered = append(offered, s)
+		}
+	}
+	if len(offered) == 0 {
+		return nil
 	}
 
-	// Get the question details
-	questionToUpdate, found := common.FindInSlice(todaysQuestions, func(question types.Question) bool {
-		return question.ID == questionID
-	})
-	if !found {
-		return fmt.Errorf("question with ID %d not found in today's questions", questionID)
+	choice := 0
+	if !queueSimilar {
+		items := make([]string, 0, len(offered)+1)
+		for _, s := range offered {
+			items = append(items, fmt.Sprintf("%d. %s (%s) - %s", s.Question.ID, s.Question.Name, s.Question.Difficulty, strings.Join(s.Reasons, ", ")))
+		}
+		items = append(items, "Not now")
+		if choice, err = common.PromptSelect("Low p-score, practice the same pattern again today?", items); err != nil {
+			return err
+		}
+		if choice == len(offered) {
+			return nil
+		}
 	}
 
-	// Display question info
-	color.Cyan("You are about to update the question: %s (ID: %d)", questionToUpdate.Name, questionToUpdate.ID)
+	if err := service.QueueSimilar(offered[choice], question); err != nil {
+		return err
+	}
+	color.Green("Added '%s' (ID: %d) to today's plan", offered[choice].Question.Name, offered[choice].Question.ID)
+	return nil
+}
 
-	// Collect user feedback
-	feedback, err := collectFeedback()
+// selectOpenQuestion asks which of today's open questions was completed, ok is false when
+// there is none
+func selectOpenQuestion(service *practice.Service) (types.Question, bool, error) {
+	plan, err := service.Today()
 	if err != nil {
-		return fmt.Errorf("collecting feedback: %w", err)
+		return types.Question{}, false, err
+	}
+	open, err := service.OpenQuestions()
+	if err != nil {
+		return types.Question{}, false, err
 	}
 
-	// Update question with feedback
-	if err := updateQuestionWithFeedback(&questionToUpdate, feedback); err != nil {
-		return fmt.Errorf("updating question: %w", err)
+	if len(plan.Questions) == 0 && len(open) == 0 {
+		color.Red("No questions found for today. Start by running 'dsacli today' to get today's questions.")
+		return types.Question{}, false, nil // This is not an error, just a message to the user
 	}
 
-	// Save to database
-	if err := db.UpdateQuestion(questionToUpdate); err != nil {
-		return fmt.Errorf("saving question: %w", err)
+	// If all questions for today are already completed, exit early
+	if len(open) == 0 {
+		color.Yellow("All questions for today are already completed. No further action needed.")
+		return types.Question{}, false, nil // No error, just a message to the user
 	}
 
-	// Mark as completed for today
-	if err := db.MarkTodayQuestionCompleted(questionID); err != nil {
-		color.Yellow("Warning: Could not mark today's question as completed: %v", err)
+	if len(plan.Questions) > 0 {
+		color.Cyan("You have completed %d out of %d questions for today.", plan.Completed(), len(plan.Questions))
 	}
 
-	color.Green("\nSuccessfully updated! '%s' (ID: %d)", questionToUpdate.Name, questionToUpdate.ID)
-	color.Cyan("Performance Score: %.2f/1.00", questionToUpdate.LastPScore)
-	color.Cyan("Review Interval: %d days", questionToUpdate.ReviewInterval)
-	color.Cyan("Review Streak: %d", questionToUpdate.ReviewStreak)
-	color.Cyan("Easiness Factor: %.2f", questionToUpdate.EasinessFactor)
-	color.Cyan("Attempt Count: %d", questionToUpdate.AttemptCount)
+	// Let user select a question
+	question, err := selectQuestion(open, service.Date())
+	if err != nil {
+		return types.Question{}, false, fmt.Errorf("selecting question: %w", err)
+	}
+	return question, true, nil
+}
 
-	if questionToUpdate.Mastered {
+// printResult shows the updated spaced repetition state of a question
+func printResult(question types.Question) {
+	color.Green("\nSuccessfully updated! '%s' (ID: %d)", question.Name, question.ID)
+	color.Cyan("Performance Score: %.2f/1.00", question.LastPScore)
+	color.Cyan("Review Interval: %d days", question.ReviewInterval)
+	color.Cyan("Review Streak: %d", question.ReviewStreak)
+	color.Cyan("Easiness Factor: %.2f", question.EasinessFactor)
+	color.Cyan("Attempt Count: %d", question.AttemptCount)
+
+	if question.Mastered {
 		color.Green("🎉 You have mastered this question.. We won't show this question again...")
 	} else {
 		color.Yellow("⏳ Working towards progression mastery...")
 	}
-
-	return nil
 }
 
-// selectQuestion prompts the user to select a question from today's questions
-func selectQuestion(questions []types.Question) (uint, error) {
+// selectQuestion prompts the user to select one of the open questions, labelling the
+// ones left over from an earlier plan than today's
+func selectQuestion(questions []types.TodayQuestionWithStatus, today string) (types.Question, error) {
 	questionPrompts := make([]string, len(questions))
-	for i, q := range questions {
-		questionPrompts[i] = fmt.Sprintf("%s (ID: %d)", q.Name, q.ID)
+	for i, qws := range questions {
+		questionPrompts[i] = fmt.Sprintf("%s (ID: %d)", qws.Question.Name, qws.Question.ID)
+		if qws.Date != today {
+			questionPrompts[i] += fmt.Sprintf(" - from %s", qws.Date)
+		}
 	}
 
 	idx, err := common.PromptSelect("Select a question", questionPrompts)
 	if err != nil {
-		return 0, fmt.Errorf("reading input: %w", err)
+		return types.Question{}, fmt.Errorf("reading input: %w", err)
 	}
 
-	return questions[idx].ID, nil
+	return questions[idx].Question, nil
 }
 
-// collectFeedback prompts the user for feedback about the completed question
-func collectFeedback() (CompletionFeedback, error) {
-	feedback := CompletionFeedback{}
+// collectFeedback prompts the user for feedback about the completed question, skipping the
+// fields given with flags. The optional fields are only prompted for when no flag was given.
+func collectFeedback(flags *pflag.FlagSet) (practice.Feedback, error) {
+	feedback := practice.Feedback{
+		HintsNeeded:     hints,
+		TimeTaken:       minutes,
+		OptimalSolution: optimality,
+		AnyBugs:         bugs,
+		Confidence:      confidence,
+		SawSolution:     sawSolution,
+		FailureCategory: failure,
+		Approach:        approach,
+	}
 
 	var err error
-	feedback.HintsNeeded, err = common.PromptInt("How many hints did you need? (Enter number of hints used, 0 if none)", common.NumberValidator)
+	if !flags.Changed("hints") {
+		if feedback.HintsNeeded, err = PromptHints(); err != nil {
+			return feedback, err
+		}
+	}
+	if !flags.Changed("time") {
+		if feedback.TimeTaken, err = PromptTime(); err != nil {
+			return feedback, err
+		}
+	}
+	if !flags.Changed("optimality") {
+		if feedback.OptimalSolution, err = PromptOptimality(); err != nil {
+			return feedback, err
+		}
+	}
+	if !flags.Changed("bugs") {
+		if feedback.AnyBugs, err = PromptBugs(); err != nil {
+			return feedback, err
+		}
+	}
+	if feedbackGiven(flags) {
+		return feedback, nil
+	}
+
+	feedback.Confidence, err = common.PromptInt("How confident are you in your solution? (1=guessing, 5=certain, 0 to skip)", common.IntCheck(practice.ValidateConfidence))
 	if err != nil {
-		return feedback, fmt.Errorf("reading hints input: %w", err)
+		return feedback, fmt.Errorf("reading confidence input: %w", err)
 	}
 
-	feedback.TimeTaken, err = common.PromptInt("How long did it take (in minutes)? (-1 if you couldn't solve without solution)", common.NumberValidator)
+	feedback.SawSolution, err = common.PromptConfirm("Did you look at the solution")
 	if err != nil {
-		return feedback, fmt.Errorf("reading time input: %w", err)
+		return feedback, fmt.Errorf("reading solution input: %w", err)
 	}
 
-	feedback.OptimalSolution, err = common.PromptInt("Was the solution optimal? (1=not optimal, 5=very optimal)", common.OneToFiveRatingValidator)
+	feedback.FailureCategory, err = selectFailureCategory()
 	if err != nil {
-		return feedback, fmt.Errorf("reading optimality input: %w", err)
+		return feedback, fmt.Errorf("reading failure input: %w", err)
 	}
 
-	feedback.AnyBugs, err = common.PromptInt("Were there any bugs? (1=many bugs, 5=no bugs)", common.OneToFiveRatingValidator)
+	feedback.Approach, err = common.PromptString("Which approach did you use? (optional, e.g. two pointers)")
 	if err != nil {
-		return feedback, fmt.Errorf("reading bugs input: %w", err)
+		return feedback, fmt.Errorf("reading approach input: %w", err)
 	}
 
 	return feedback, nil
 }
 
-// updateQuestionWithFeedback updates the question with the user's feedback using spaced repetition
-func updateQuestionWithFeedback(question *types.Question, feedback CompletionFeedback) error {
-	ProcessReview(question, feedback.TimeTaken, feedback.HintsNeeded, feedback.OptimalSolution, feedback.AnyBugs)
+// feedbackGiven reports whether any feedback was passed as a flag. Other flags such as the global
+// --now or --queue-similar don't count, they don't replace any prompt.
+func feedbackGiven(flags *pflag.FlagSet) bool {
+	for _, name := range feedbackFlags {
+		if flags.Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// PromptHints asks for the number of hints used
+func PromptHints() (int, error) {
+	value, err := common.PromptInt(fmt.Sprintf("How many hints did you need? (0-%d, 0 if none)", practice.MaxHints), common.IntCheck(practice.ValidateHints))
+	if err != nil {
+		return 0, fmt.Errorf("reading hints input: %w", err)
+	}
+	return value, nil
+}
 
-	// Update question fields
-	now := time.Now()
-	question.LastReviewed = &now
-	question.Attempted = true
+// PromptTime asks for the minutes taken
+func PromptTime() (int, error) {
+	value, err := common.PromptInt(fmt.Sprintf("How long did it take (in minutes)? (%d if you couldn't solve without solution)", practice.UnsolvedTimeValue), common.IntCheck(practice.ValidateTimeTaken))
+	if err != nil {
+		return 0, fmt.Errorf("reading time input: %w", err)
+	}
+	return value, nil
+}
 
-	return nil
+// PromptOptimality asks how optimal the solution was
+func PromptOptimality() (int, error) {
+	value, err := common.PromptInt("Was the solution optimal? (1=not optimal, 5=very optimal)", common.IntCheck(func(v int) error {
+		return practice.ValidateRating("optimality", v)
+	}))
+	if err != nil {
+		return 0, fmt.Errorf("reading optimality input: %w", err)
+	}
+	return value, nil
+}
+
+// PromptBugs asks how buggy the solution was
+func PromptBugs() (int, error) {
+	value, err := common.PromptInt("Were there any bugs? (1=many bugs, 5=no bugs)", common.IntCheck(func(v int) error {
+		return practice.ValidateRating("bugs", v)
+	}))
+	if err != nil {
+		return 0, fmt.Errorf("reading bugs input: %w", err)
+	}
+	return value, nil
+}
+
+// selectFailureCategory asks what went wrong during the attempt, "" if nothing did
+func selectFailureCategory() (string, error) {
+	options := []string{"Nothing went wrong"}
+	for _, category := range types.FailureCategories {
+		options = append(options, strings.ToUpper(types.FailureDescriptions[category][:1])+types.FailureDescriptions[category][1:])
+	}
+	idx, err := common.PromptSelect("What went wrong?", options)
+	if err != nil {
+		return "", err
+	}
+	if idx == 0 {
+		return "", nil
+	}
+	return types.FailureCategories[idx-1], nil
 }
@@ -0,0 +1,44 @@
+package complete
+
+import (
+	"dsacli/config"
+	"dsacli/db/dbtest"
+	"dsacli/practice"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+var testConfig = config.NewConfig("test.db")
+
+func TestFeedbackGiven(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected bool
+	}{
+		{name: "No flags", args: nil, expected: false},
+		{name: "Global and similar flags only", args: []string{"--now", "2024-03-10", "--seed", "1", "--queue-similar"}, expected: false},
+		{name: "Feedback flag", args: []string{"--now", "2024-03-10", "--hints", "1"}, expected: true},
+		{name: "Optional feedback flag", args: []string{"--approach", "two pointers"}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := &cobra.Command{Use: "dsacli"}
+			root.PersistentFlags().String("now", "", "")
+			root.PersistentFlags().Int64("seed", 0, "")
+			command := GetCommand(practice.NewService(&dbtest.MockDatabase{}, testConfig))
+			command.Run = func(cmd *cobra.Command, args []string) {}
+			root.AddCommand(command)
+
+			root.SetArgs(append([]string{"complete"}, tt.args...))
+			if err := root.Execute(); err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+			if got := feedbackGiven(command.Flags()); got != tt.expected {
+				t.Errorf("feedbackGiven() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -1,79 +1,77 @@
 package complete
 
 import (
-	"dsacli/db"
+	"dsacli/practice"
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
 // GetProgressCommand returns a command to check progression gate status
-func GetProgressCommand(database db.Database) *cobra.Command {
+func GetProgressCommand(service *practice.Service) *cobra.Command {
 	return &cobra.Command{
 		Use:   "progress",
 		Short: "Check progression gate status for difficulty tiers",
 		Long:  `Check the mastery percentage for each difficulty tier and see which tiers are unlocked.`,
-		Run:   progressCmd(database),
+		Run:   progressCmd(service),
 	}
 }
 
-func progressCmd(database db.Database) func(cmd *cobra.Command, args []string) {
+func progressCmd(service *practice.Service) func(cmd *cobra.Command, args []string) {
 	return func(cmd *cobra.Command, args []string) {
-		if err := executeProgress(database); err != nil {
+		if err := executeProgress(service); err != nil {
 			color.Red("Error: %v", err)
 			return
 		}
 	}
 }
 
-func executeProgress(database db.Database) error {
-	difficulties := []string{"easy", "medium", "hard"}
+func executeProgress(service *practice.Service) error {
+	tiers, err := service.Progress()
+	if err != nil {
+		return err
+	}
 
 	color.Cyan("🎯 Progression Gate Status\n")
 
-	for _, difficulty := range difficulties {
-		questions, err := database.GetQuestionsByDifficulty(difficulty)
-		if err != nil {
-			return fmt.Errorf("failed to get %s questions: %w", difficulty, err)
-		}
-
-		if len(questions) == 0 {
-			color.Yellow("No %s questions found", difficulty)
+	for _, tier := range tiers {
+		if tier.Total == 0 {
+			color.Yellow("No %s questions found", tier.Difficulty)
 			continue
 		}
 
-		masteredCount := 0
-		for _, q := range questions {
-			if q.Mastered {
-				masteredCount++
-			}
-		}
-
-		masteryPercentage := float64(masteredCount) / float64(len(questions)) * 100
-		isUnlocked := masteryPercentage > 50.0
-
 		statusIcon := "🔒"
 		statusColor := color.Red
-		if isUnlocked {
+		if tier.Unlocked {
 			statusIcon = "🔓"
 			statusColor = color.Green
 		}
 
 		statusColor("%s %s: %d/%d mastered (%.1f%%)",
-			statusIcon, difficulty, masteredCount, len(questions), masteryPercentage)
+			statusIcon, tier.Difficulty, tier.Mastered, tier.Total, tier.MasteryPercentage)
 
-		if isUnlocked {
+		if tier.Unlocked {
 			color.Green("   ✅ Unlocked - You can progress to the next tier!")
 		} else {
-			needed := int(float64(len(questions))*0.51) - masteredCount
-			if needed <= 0 {
-				needed = 1
-			}
-			color.Yellow("   ⏳ Need %d more mastered questions to unlock", needed)
+			printMissing(tier)
 		}
 		fmt.Println()
 	}
 
 	return nil
 }
+
+// printMissing lists the criteria of the tier's gate that aren't met yet
+func printMissing(tier practice.TierProgress) {
+	if tier.Needed > 0 {
+		color.Yellow("   ⏳ Need %d more mastered questions to unlock", tier.Needed)
+	}
+	if tier.AttemptsNeeded > 0 {
+		color.Yellow("   ⏳ Need %d more attempts (%d/%d)", tier.AttemptsNeeded, tier.Attempts, tier.Gate.MinAttempts)
+	}
+	if len(tier.UncoveredTags) > 0 {
+		color.Yellow("   ⏳ Attempt %.0f%% of the questions of: %s", tier.Gate.TagCoverage, strings.Join(tier.UncoveredTags, ", "))
+	}
+}
@@ -0,0 +1,113 @@
+package goal
+
+import (
+	"dsacli/common"
+	"dsacli/practice"
+	"errors"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	interview string
+	focus     string
+)
+
+func GetCommand(service *practice.Service) *cobra.Command {
+	Command := &cobra.Command{
+		Use:   "goal",
+		Short: "Show the interview goal",
+		Long: `Show the interview goal the schedule is compressed towards and how ready you are projected to be.
+Set one with "dsacli goal set".`,
+		Args: cobra.NoArgs,
+		Run:  goalCmd(service),
+	}
+
+	setCommand := &cobra.Command{
+		Use:   "set",
+		Short: "Set an interview date to prepare for",
+		Long: `Set an interview date to prepare for. Until then review intervals are capped so every question
+comes up again before the final week, more new questions are planned early on (questions with
+a focus tag first), and the final week only reviews questions that aren't mastered yet.`,
+		Args: cobra.NoArgs,
+		Run:  setCmd(service),
+	}
+	setCommand.Flags().StringVar(&interview, "interview", "", "Interview date (YYYY-MM-DD)")
+	setCommand.Flags().StringVar(&focus, "focus", "", "Comma-separated tags to prioritize")
+	setCommand.MarkFlagRequired("interview")
+	Command.AddCommand(setCommand)
+
+	Command.AddCommand(&cobra.Command{
+		Use:   "clear",
+		Short: "Remove the interview goal",
+		Args:  cobra.NoArgs,
+		Run:   clearCmd(service),
+	})
+
+	return Command
+}
+
+func goalCmd(service *practice.Service) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executeGoal(service); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+func executeGoal(service *practice.Service) error {
+	status, err := service.Status()
+	if err != nil {
+		return err
+	}
+	if status.Readiness == nil {
+		color.Cyan("No interview goal is set. Use 'dsacli goal set --interview YYYY-MM-DD' to set one.")
+		return nil
+	}
+	PrintReadiness(*status.Readiness)
+	return nil
+}
+
+// PrintReadiness shows the days left until the interview and the projected mastery
+func PrintReadiness(readiness practice.Readiness) {
+	color.Cyan("Interview on %s (%d days left)", readiness.InterviewDate, readiness.DaysLeft)
+	color.White("    Mastered now: %.0f%%, projected by the interview: ~%.0f%%", readiness.MasteredPercentage, readiness.ProjectedPercentage)
+	for _, tag := range readiness.Focus {
+		color.White("    %s: %d/%d mastered", tag.Tag, tag.Mastered, tag.Total)
+	}
+	if readiness.DaysLeft <= practice.FinalWeekDays {
+		color.Yellow("    Final week: only questions that aren't mastered yet are reviewed")
+	}
+}
+
+func setCmd(service *practice.Service) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		goal, err := service.SetGoal(interview, common.SplitCSV(focus))
+		if err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+		color.Green("Preparing for your interview on %s", goal.InterviewDate)
+		if len(goal.Focus) > 0 {
+			color.Green("Focusing on: %s", strings.Join(goal.Focus, ", "))
+		}
+	}
+}
+
+func clearCmd(service *practice.Service) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		err := service.ClearGoal()
+		if errors.Is(err, practice.ErrNoGoal) {
+			color.Yellow("No interview goal is set.")
+			return
+		}
+		if err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+		color.Green("Interview goal removed")
+	}
+}
@@ -0,0 +1,93 @@
+package graph
+
+import (
+	"dsacli/practice"
+	"dsacli/types"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var dot bool
+
+// dotColors are the outline colours of the questions of each difficulty in the DOT export
+var dotColors = map[string]string{
+	types.DifficultyEasy:   "green",
+	types.DifficultyMedium: "orange",
+	types.DifficultyHard:   "red",
+}
+
+func GetCommand(service *practice.Service) *cobra.Command {
+	Command := &cobra.Command{
+		Use:   "graph",
+		Short: "Show the prerequisites between questions",
+		Long: `Show which questions have to be attempted before others. New questions are only planned once
+their prerequisites were attempted. Use --dot to export the graph for Graphviz, e.g.
+"dsacli graph --dot | dot -Tsvg -o graph.svg".`,
+		Args: cobra.NoArgs,
+		Run:  graphCmd(service),
+	}
+	Command.Flags().BoolVar(&dot, "dot", false, "Print the graph in Graphviz DOT format")
+	return Command
+}
+
+func graphCmd(service *practice.Service) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executeGraph(service); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+func executeGraph(service *practice.Service) error {
+	graph, err := service.Graph()
+	if err != nil {
+		return err
+	}
+	if dot {
+		fmt.Print(toDOT(graph))
+		return nil
+	}
+
+	if len(graph.Dependencies) == 0 {
+		color.Yellow("No prerequisites between questions. Add them with \"prerequisites\" in a seed file.")
+		return nil
+	}
+	names := make(map[uint]string, len(graph.Questions))
+	for _, q := range graph.Questions {
+		names[q.ID] = q.Name
+	}
+	color.Cyan("Prerequisites (%d):", len(graph.Dependencies))
+	for _, d := range graph.Dependencies {
+		fmt.Printf("  %d. %s -> %d. %s\n", d.PrerequisiteID, names[d.PrerequisiteID], d.QuestionID, names[d.QuestionID])
+	}
+	return nil
+}
+
+// toDOT renders the graph with an edge from every prerequisite to its dependent. Attempted
+// questions are filled in.
+func toDOT(graph practice.Graph) string {
+	var b strings.Builder
+	b.WriteString("digraph prerequisites {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, q := range graph.Questions {
+		outline, ok := dotColors[q.Difficulty]
+		if !ok {
+			outline = "black"
+		}
+		attrs := fmt.Sprintf("label=%q, color=%s", fmt.Sprintf("%d. %s", q.ID, q.Name), outline)
+		if q.Attempted {
+			attrs += ", style=filled, fillcolor=lightgrey"
+		}
+		fmt.Fprintf(&b, "  q%d [%s];\n", q.ID, attrs)
+	}
+	for _, d := range graph.Dependencies {
+		fmt.Fprintf(&b, "  q%d -> q%d;\n", d.PrerequisiteID, d.QuestionID)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,154 @@
+package leeches
+
+import (
+	"dsacli/common"
+	"dsacli/practice"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand(service *practice.Service) *cobra.Command {
+	Command := &cobra.Command{
+		Use:   "leeches",
+		Short: "List questions that keep failing",
+		Long: `List the leeches: questions that failed their recalls so often they just keep coming back.
+They are tagged "leech" (see "dsacli config set leech-threshold"). For each one easier questions
+sharing a tag are suggested to practice first; write down what trips you up in its notes, or
+suspend it until you are ready to try again.`,
+		Args: cobra.NoArgs,
+		Run:  leechesCmd(service),
+	}
+
+	Command.AddCommand(&cobra.Command{
+		Use:   "suspend [question_id]",
+		Short: "Stop scheduling a question until it is resumed",
+		Args:  cobra.ExactArgs(1),
+		Run:   suspendCmd(service, true),
+	})
+	Command.AddCommand(&cobra.Command{
+		Use:   "resume [question_id]",
+		Short: "Schedule a suspended question again",
+		Args:  cobra.ExactArgs(1),
+		Run:   suspendCmd(service, false),
+	})
+
+	return Command
+}
+
+func leechesCmd(service *practice.Service) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executeLeeches(service); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+func executeLeeches(service *practice.Service) error {
+	leeches, err := service.Leeches()
+	if err != nil {
+		return err
+	}
+	if len(leeches) == 0 {
+		color.Green("No leeches, nothing keeps failing.")
+		return nil
+	}
+
+	color.Red("Leeches (%d):", len(leeches))
+	for _, leech := range leeches {
+		q := leech.Question
+		suffix := ""
+		if q.Suspended {
+			suffix = " [suspended]"
+		}
+		color.Cyan("\n%d. %s (%s)%s", q.ID, q.Name, q.Difficulty, suffix)
+		fmt.Printf("    %d failed recalls, easiness factor %.2f\n", leech.Failures, q.EasinessFactor)
+		PrintRemedies(leech)
+	}
+
+	fmt.Println("\nAdd notes with 'dsacli question edit <question_id> --notes ...', set a leech aside with 'dsacli leeches suspend <question_id>'.")
+	return nil
+}
+
+// PrintRemedies suggests the easier questions to practice before a leech
+func PrintRemedies(leech practice.Leech) {
+	if len(leech.Easier) == 0 {
+		fmt.Println("    It has no prerequisites and no easier questions share a tag with it.")
+		return
+	}
+	fmt.Println("    Practice these prerequisites and easier questions first:")
+	for _, q := range leech.Easier {
+		fmt.Printf("      - %d. %s (%s, %s)\n", q.ID, q.Name, q.Difficulty, strings.Join(q.Tags, ", "))
+	}
+}
+
+// Remediate walks through the options for a question that just became a leech: it lists the
+// easier questions, asks for notes on what went wrong and offers to suspend it
+func Remediate(service *practice.Service, leech practice.Leech) error {
+	q := leech.Question
+	color.Red("\n'%s' failed %d recalls and is now tagged as a leech.", q.Name, leech.Failures)
+	PrintRemedies(leech)
+
+	notes, err := common.PromptString("What keeps tripping you up? (added to the notes, leave empty to skip)")
+	if err != nil {
+		return err
+	}
+	if notes != "" {
+		if q.Notes != "" {
+			notes = q.Notes + "\n" + notes
+		}
+		if _, err := service.SaveNotes(q.ID, notes); err != nil {
+			return err
+		}
+	}
+
+	suspend, err := common.PromptConfirm("Suspend it until you resume it with 'dsacli leeches resume'")
+	if err != nil || !suspend {
+		return err
+	}
+	if _, err := service.SuspendQuestion(q.ID); err != nil {
+		return err
+	}
+	color.Yellow("Suspended '%s', it won't be scheduled until you resume it.", q.Name)
+	return nil
+}
+
+func suspendCmd(service *practice.Service, suspend bool) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executeSuspend(service, args, suspend); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+func executeSuspend(service *practice.Service, args []string, suspend bool) error {
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid question id %q", args[0])
+	}
+
+	update := service.ResumeQuestion
+	if suspend {
+		update = service.SuspendQuestion
+	}
+	question, err := update(uint(id))
+	if errors.Is(err, practice.ErrQuestionNotFound) {
+		return fmt.Errorf("question with ID %d not found", id)
+	}
+	if err != nil {
+		return err
+	}
+
+	if suspend {
+		color.Green("Suspended '%s' (ID: %d). It won't be scheduled until you resume it.", question.Name, question.ID)
+	} else {
+		color.Green("Resumed '%s' (ID: %d). It will be scheduled again.", question.Name, question.ID)
+	}
+	return nil
+}
@@ -1,9 +1,11 @@
 package list
 
 import (
+	"dsacli/common"
 	"dsacli/db"
 	"dsacli/types"
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -12,30 +14,51 @@ import (
 var shortForm = true
 var longForm = false
 
+var (
+	difficulties string
+	attempted    bool
+	unattempted  bool
+	mastered     bool
+	due          bool
+	pScoreBelow  float64
+	tag          string
+	sortBy       string
+)
+
 func GetCommand(db db.Database) *cobra.Command {
 	Command := &cobra.Command{
 		Use:   "list",
 		Short: "List all questions with their IDs",
-		Long:  `List all questions with their IDs, completion status, and SR scores.`,
-		Run:   listCmd(db),
+		Long: `List all questions with their IDs, completion status, and SR scores.
+
+Passing any filter or --sort prints a single list of the matching questions instead of the per difficulty summary.`,
+		Run: listCmd(db),
 	}
 
 	Command.Flags().BoolVarP(&shortForm, "short", "s", true, "Prints category wise stats only")
 	Command.Flags().BoolVarP(&longForm, "long", "l", false, "Prints all questions with IDs, completion status, and SR scores")
+	Command.Flags().StringVar(&difficulties, "difficulty", "", "Only show questions of these difficulties (e.g. medium,hard)")
+	Command.Flags().BoolVar(&attempted, "attempted", false, "Only show attempted questions")
+	Command.Flags().BoolVar(&unattempted, "unattempted", false, "Only show unattempted questions")
+	Command.Flags().BoolVar(&mastered, "mastered", false, "Only show mastered questions")
+	Command.Flags().BoolVar(&due, "due", false, "Only show questions that are due for review")
+	Command.Flags().Float64Var(&pScoreBelow, "pscore-below", 0, "Only show attempted questions with a p-score below this value (e.g. 0.6)")
+	Command.Flags().StringVar(&tag, "tag", "", "Only show questions with this tag")
+	Command.Flags().StringVar(&sortBy, "sort", "", "Sort by pscore (lowest first), due (soonest first), attempts (most first) or name")
 
 	return Command
 }
 
 func listCmd(db db.Database) func(cmd *cobra.Command, args []string) {
 	return func(cmd *cobra.Command, args []string) {
-		if err := executeList(db); err != nil {
+		if err := executeList(db, cmd); err != nil {
 			color.Red("Error: %v", err)
 			return
 		}
 	}
 }
 
-func executeList(db db.Database) error {
+func executeList(db db.Database, cmd *cobra.Command) error {
 	if longForm {
 		shortForm = false
 	}
@@ -44,6 +67,14 @@ func executeList(db db.Database) error {
 		shortForm = true
 	}
 
+	filter, filtered, err := buildFilter(cmd)
+	if err != nil {
+		return err
+	}
+	if filtered {
+		return executeFilteredList(db, filter)
+	}
+
 	questions, err := db.GetAllQuestions()
 	if err != nil {
 		return fmt.Errorf("error loading questions: %v", err)
@@ -76,7 +107,7 @@ func executeList(db db.Database) error {
 				status = "✅"
 			}
 
-			longFormLogs = append(longFormLogs, fmt.Sprintf("  %s ID:%d - %s (P Score: %f)\n", status, q.ID, q.Name, q.LastPScore))
+			longFormLogs = append(longFormLogs, fmt.Sprintf("  %s ID:%d - %s (P Score: %f)%s\n", status, q.ID, q.Name, q.LastPScore, stateSuffix(q)))
 		}
 
 		color.White("	- Total Attempted: %d\n", totalAttempted)
@@ -99,3 +130,85 @@ func executeList(db db.Database) error {
 
 	return nil
 }
+
+// buildFilter converts the command flags into a database filter.
+// The returned bool reports whether any filter or sort flag was provided.
+func buildFilter(cmd *cobra.Command) (db.QuestionFilter, bool, error) {
+	flags := cmd.Flags()
+	filter := db.QuestionFilter{IncludeSuspended: true}
+	filtered := false
+
+	if flags.Changed("difficulty") {
+		filter.Difficulties = common.SplitCSV(difficulties)
+		for _, d := range filter.Difficulties {
+			if !types.IsValidDifficulty(d) {
+				return filter, false, fmt.Errorf("invalid difficulty %q, expected one of %s", d, strings.Join(types.Difficulties, ", "))
+			}
+		}
+		filtered = true
+	}
+	if attempted && unattempted {
+		return filter, false, fmt.Errorf("--attempted and --unattempted cannot be used together")
+	}
+	if attempted || unattempted {
+		filter.Attempted = &attempted
+		filtered = true
+	}
+	if mastered {
+		filter.Mastered = &mastered
+		filtered = true
+	}
+	if due {
+		filter.Due = true
+		filtered = true
+	}
+	if flags.Changed("pscore-below") {
+		filter.PScoreBelow = &pScoreBelow
+		filtered = true
+	}
+	if tag != "" {
+		filter.Tag = tag
+		filtered = true
+	}
+	if sortBy != "" {
+		filter.Sort = strings.ToLower(sortBy)
+		filtered = true
+	}
+
+	return filter, filtered, nil
+}
+
+func executeFilteredList(database db.Database, filter db.QuestionFilter) error {
+	questions, err := database.FilterQuestions(filter)
+	if err != nil {
+		return fmt.Errorf("error loading questions: %v", err)
+	}
+
+	if len(questions) == 0 {
+		color.Yellow("No questions match the given filters.")
+		return nil
+	}
+
+	color.Cyan("Matching DSA Questions (%d):", len(questions))
+	fmt.Println()
+	for _, q := range questions {
+		status := "❌"
+		if q.Attempted {
+			status = "✅"
+		}
+		fmt.Printf("  %s ID:%d - %s [%s] (P Score: %f, Attempts: %d)%s\n", status, q.ID, q.Name, q.Difficulty, q.LastPScore, q.AttemptCount, stateSuffix(q))
+	}
+
+	fmt.Println("\nTo mark a question as complete, use: dsacli complete <question_id>")
+	return nil
+}
+
+func stateSuffix(q types.Question) string {
+	if q.Archived {
+		return " [archived]"
+	}
+	if q.Suspended {
+		return " [suspended]"
+	}
+	return ""
+}
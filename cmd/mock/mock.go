@@ -0,0 +1,147 @@
+package mock
+
+import (
+	"dsacli/cmd/complete"
+	"dsacli/cmd/today"
+	"dsacli/common"
+	"dsacli/practice"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	solvedOption = "Solved"
+	gaveUpOption = "Gave up"
+)
+
+var (
+	duration     int
+	count        int
+	difficulties string
+)
+
+func GetCommand(service *practice.Service) *cobra.Command {
+	Command := &cobra.Command{
+		Use:   "mock",
+		Short: "Run a timed mock interview",
+		Long: `Run a timed mock interview on questions you haven't seen yet or that are due for review.
+Problem names stay hidden until the session starts, hints aren't allowed and the time taken is
+measured for you. Problems solved after the time is up count as unsolved. Every problem is recorded
+as an attempt, so mocks count towards your spaced repetition schedule.`,
+		Args: cobra.NoArgs,
+		Run:  mockCmd(service),
+	}
+
+	Command.Flags().IntVar(&duration, "duration", 45, "Minutes for the whole session")
+	Command.Flags().IntVar(&count, "count", 2, "Number of problems")
+	Command.Flags().StringVar(&difficulties, "difficulty", "", "Comma-separated difficulties to pick from (default all)")
+
+	return Command
+}
+
+func mockCmd(service *practice.Service) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executeMock(service); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+func executeMock(service *practice.Service) error {
+	opts := practice.MockOptions{Duration: duration, Count: count, Difficulties: common.SplitCSV(difficulties)}
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	pool := "any difficulty"
+	if len(opts.Difficulties) > 0 {
+		pool = strings.Join(opts.Difficulties, "/")
+	}
+	color.Cyan("Mock interview: %d problem(s) of %s in %d minutes. No hints allowed.", opts.Count, pool, opts.Duration)
+	ready, err := common.PromptConfirm("Start the clock")
+	if err != nil {
+		return err
+	}
+	if !ready {
+		return nil
+	}
+
+	mock, err := service.StartMock(opts)
+	if err != nil {
+		return err
+	}
+
+	for i, q := range mock.Questions {
+		left := mock.Deadline.Sub(service.Now())
+		if left <= 0 {
+			color.Red("Time's up!")
+			break
+		}
+
+		color.Cyan("\nProblem %d/%d: %s (%s) - %s left", i+1, len(mock.Questions), q.Name, q.Difficulty, formatDuration(left))
+		fmt.Println(q.URL)
+		if err := today.OpenBrowser(q.URL); err != nil {
+			color.Yellow("Couldn't open the browser: %v", err)
+		}
+
+		started := service.Now()
+		choice, err := common.PromptSelect("How did it go?", []string{solvedOption, gaveUpOption})
+		if err != nil {
+			return fmt.Errorf("reading input: %w", err)
+		}
+
+		result := practice.MockResult{Solved: choice == 0, Elapsed: service.Now().Sub(started)}
+		if result.Solved && service.Now().After(mock.Deadline) {
+			color.Red("Time ran out before you finished, this problem counts as unsolved.")
+			result.Solved = false
+		}
+		if result.Solved {
+			if result.Optimality, err = complete.PromptOptimality(); err != nil {
+				return err
+			}
+			if result.Bugs, err = complete.PromptBugs(); err != nil {
+				return err
+			}
+		}
+
+		if _, err := service.RecordMockAttempt(mock, q.ID, result); err != nil {
+			return err
+		}
+	}
+
+	summary, err := service.FinishMock(mock)
+	if err != nil {
+		return err
+	}
+	printSummary(summary)
+	return nil
+}
+
+// printSummary shows the score of the session and how each problem went
+func printSummary(summary practice.MockSummary) {
+	session := summary.Session
+	color.Cyan("\nMock interview summary")
+	fmt.Printf("Time used: %s of %d minutes\n", formatDuration(session.EndedAt.Sub(session.StartedAt)), session.DurationMinutes)
+	for i, problem := range summary.Problems {
+		switch {
+		case problem.Attempt == nil:
+			color.Yellow("    %d. %s (%s): not started", i+1, problem.Question.Name, problem.Question.Difficulty)
+		case problem.Attempt.TimeTaken == practice.UnsolvedTimeValue:
+			color.Red("    %d. %s (%s): unsolved, p-score %.2f", i+1, problem.Question.Name, problem.Question.Difficulty, problem.Attempt.PScore)
+		default:
+			color.Green("    %d. %s (%s): solved in %d min, p-score %.2f", i+1, problem.Question.Name, problem.Question.Difficulty, problem.Attempt.TimeTaken, problem.Attempt.PScore)
+		}
+	}
+	color.Cyan("Score: %.0f%%", session.Score*100)
+}
+
+// formatDuration formats a duration as whole minutes and seconds
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
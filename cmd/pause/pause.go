@@ -0,0 +1,75 @@
+package pause
+
+import (
+	"dsacli/practice"
+	"errors"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	until  string
+	resume bool
+)
+
+func GetCommand(service *practice.Service) *cobra.Command {
+	Command := &cobra.Command{
+		Use:   "pause",
+		Short: "Pause practice for a vacation",
+		Long: `Pause practice until the given date. No questions are planned while paused and every due date
+is pushed back by the length of the pause, so reviews don't pile up while you're away.
+Without flags, shows whether practice is paused.`,
+		Args: cobra.NoArgs,
+		Run:  pauseCmd(service),
+	}
+
+	Command.Flags().StringVar(&until, "until", "", "First day to practice again (YYYY-MM-DD)")
+	Command.Flags().BoolVar(&resume, "resume", false, "End the pause early")
+	Command.MarkFlagsMutuallyExclusive("until", "resume")
+
+	return Command
+}
+
+func pauseCmd(service *practice.Service) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executePause(service); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+func executePause(service *practice.Service) error {
+	switch {
+	case until != "":
+		pause, err := service.Pause(until)
+		if err != nil {
+			return err
+		}
+		color.Green("Practice paused until %s. Due dates have been pushed back, enjoy the break!", pause.Until)
+
+	case resume:
+		pause, err := service.Resume()
+		if errors.Is(err, practice.ErrNotPaused) {
+			color.Yellow("Practice is not paused.")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		color.Green("Welcome back! The pause that was planned until %s has ended.", pause.Until)
+
+	default:
+		pause, paused, err := service.ActivePause()
+		if err != nil {
+			return err
+		}
+		if !paused {
+			color.Cyan("Practice is not paused. Use --until to pause it.")
+			return nil
+		}
+		color.Yellow("Practice is paused since %s until %s. Use --resume to end the pause early.", pause.StartDate, pause.Until)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package question
+
+import (
+	"dsacli/db"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+func getArchiveCommand(db db.Database) *cobra.Command {
+	return &cobra.Command{
+		Use:   "archive [question_id]",
+		Short: "Exclude a question from scheduling",
+		Long:  `Archive a question so it is no longer scheduled. Its review history is kept and it can be restored with 'question unarchive'.`,
+		Args:  cobra.ExactArgs(1),
+		Run:   archiveCmd(db, true),
+	}
+}
+
+func getUnarchiveCommand(db db.Database) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unarchive [question_id]",
+		Short: "Make an archived question available for scheduling again",
+		Long:  `Restore an archived question so it is scheduled again, along with its previous review history.`,
+		Args:  cobra.ExactArgs(1),
+		Run:   archiveCmd(db, false),
+	}
+}
+
+func archiveCmd(db db.Database, archive bool) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executeArchive(db, args, archive); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+func executeArchive(db db.Database, args []string, archive bool) error {
+	id, err := parseQuestionID(args[0])
+	if err != nil {
+		return err
+	}
+
+	question, err := findQuestion(db, id)
+	if err != nil {
+		return err
+	}
+
+	if archive {
+		if err := db.ArchiveQuestion(id); err != nil {
+			return fmt.Errorf("archiving question: %w", err)
+		}
+		color.Green("Archived '%s' (ID: %d). It will no longer be scheduled.", question.Name, question.ID)
+		return nil
+	}
+
+	if err := db.UnarchiveQuestion(id); err != nil {
+		return fmt.Errorf("unarchiving question: %w", err)
+	}
+	color.Green("Restored '%s' (ID: %d). It will be scheduled again.", question.Name, question.ID)
+	return nil
+}
@@ -0,0 +1,107 @@
+package question
+
+import (
+	"dsacli/common"
+	"dsacli/db"
+	"dsacli/types"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+func getEditCommand(db db.Database) *cobra.Command {
+	opts := &questionOptions{}
+	Command := &cobra.Command{
+		Use:   "edit [question_id]",
+		Short: "Edit an existing question",
+		Long:  `Edit the name, URL, difficulty, tags, section, companies, lists, platform, premium flag or notes of an existing question. Only the flags provided are updated. Changing the URL derives the platform again unless --platform is given.`,
+		Args:  cobra.ExactArgs(1),
+		Run:   editCmd(db, opts),
+	}
+
+	Command.Flags().StringVar(&opts.name, "name", "", "New name of the question")
+	Command.Flags().StringVar(&opts.url, "url", "", "New URL of the question")
+	Command.Flags().StringVar(&opts.difficulty, "difficulty", "", "New difficulty of the question (easy, medium, hard)")
+	Command.Flags().StringVar(&opts.tags, "tags", "", "Comma separated list of tags, replaces the existing tags")
+	Command.Flags().StringVar(&opts.section, "section", "", "New section of the problem list it comes from")
+	Command.Flags().StringVar(&opts.companies, "companies", "", "Comma separated list of companies known to ask it, replaces the existing companies")
+	Command.Flags().StringVar(&opts.lists, "lists", "", "Comma separated list of curated lists it belongs to, replaces the existing lists")
+	Command.Flags().StringVar(&opts.platform, "platform", "", "New platform hosting it ("+strings.Join(types.Platforms, ", ")+")")
+	Command.Flags().BoolVar(&opts.premium, "premium", false, "Whether the question is locked behind a paid subscription (--premium=false to unset)")
+	Command.Flags().StringVar(&opts.notes, "notes", "", "Personal notes for the question, replaces the existing notes")
+
+	return Command
+}
+
+func editCmd(db db.Database, opts *questionOptions) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executeEdit(db, opts, cmd, args); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+func executeEdit(db db.Database, opts *questionOptions, cmd *cobra.Command, args []string) error {
+	id, err := parseQuestionID(args[0])
+	if err != nil {
+		return err
+	}
+
+	question, err := findQuestion(db, id)
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	if !flags.Changed("name") && !flags.Changed("url") && !flags.Changed("difficulty") &&
+		!flags.Changed("tags") && !flags.Changed("section") && !flags.Changed("companies") &&
+		!flags.Changed("lists") && !flags.Changed("platform") && !flags.Changed("premium") && !flags.Changed("notes") {
+		return fmt.Errorf("nothing to update, provide at least one of --name, --url, --difficulty, --tags, --section, --companies, --lists, --platform, --premium or --notes")
+	}
+
+	if flags.Changed("name") {
+		question.Name = strings.TrimSpace(opts.name)
+	}
+	if flags.Changed("url") {
+		question.URL = strings.TrimSpace(opts.url)
+		question.Platform = types.PlatformFromURL(question.URL)
+	}
+	if flags.Changed("difficulty") {
+		question.Difficulty = strings.ToLower(strings.TrimSpace(opts.difficulty))
+	}
+	if flags.Changed("tags") {
+		question.Tags = common.SplitCSV(opts.tags)
+	}
+	if flags.Changed("section") {
+		question.Section = strings.TrimSpace(opts.section)
+	}
+	if flags.Changed("companies") {
+		question.Companies = common.SplitCSV(opts.companies)
+	}
+	if flags.Changed("lists") {
+		question.Lists = common.SplitCSV(opts.lists)
+	}
+	if flags.Changed("platform") {
+		question.Platform = strings.ToLower(strings.TrimSpace(opts.platform))
+	}
+	if flags.Changed("premium") {
+		question.Premium = opts.premium
+	}
+	if flags.Changed("notes") {
+		question.Notes = opts.notes
+	}
+
+	if err := validateQuestion(question); err != nil {
+		return err
+	}
+
+	if err := db.UpdateQuestion(question); err != nil {
+		return fmt.Errorf("saving question: %w", err)
+	}
+
+	color.Green("Successfully updated '%s' (ID: %d)", question.Name, question.ID)
+	return nil
+}
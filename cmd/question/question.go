@@ -0,0 +1,143 @@
+package question
+
+import (
+	"dsacli/common"
+	"dsacli/db"
+	"dsacli/types"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// questionOptions holds the flags of a command adding or editing a question. Each command
+// gets its own so add and edit don't share state.
+type questionOptions struct {
+	name       string
+	url        string
+	difficulty string
+	tags       string
+	section    string
+	companies  string
+	lists      string
+	platform   string
+	premium    bool
+	notes      string
+}
+
+// GetCommand returns the parent command used to manage the question bank
+func GetCommand(db db.Database) *cobra.Command {
+	Command := &cobra.Command{
+		Use:   "question",
+		Short: "Manage the question bank",
+		Long:  `Add, edit, remove or archive questions without editing JSON files and re-seeding.`,
+	}
+
+	Command.AddCommand(getAddCommand(db))
+	Command.AddCommand(getEditCommand(db))
+	Command.AddCommand(getRemoveCommand(db))
+	Command.AddCommand(getArchiveCommand(db))
+	Command.AddCommand(getUnarchiveCommand(db))
+
+	return Command
+}
+
+func getAddCommand(db db.Database) *cobra.Command {
+	opts := &questionOptions{}
+	Command := &cobra.Command{
+		Use:   "add",
+		Short: "Add a new question to the bank",
+		Long:  `Add a new question to the bank. The question is available for scheduling immediately.`,
+		Args:  cobra.NoArgs,
+		Run:   addCmd(db, opts),
+	}
+
+	Command.Flags().StringVar(&opts.name, "name", "", "Name of the question")
+	Command.Flags().StringVar(&opts.url, "url", "", "URL of the question")
+	Command.Flags().StringVar(&opts.difficulty, "difficulty", "", "Difficulty of the question (easy, medium, hard)")
+	Command.Flags().StringVar(&opts.tags, "tags", "", "Comma separated list of tags (e.g. graphs,bfs)")
+	Command.Flags().StringVar(&opts.section, "section", "", "Section of the problem list it comes from (e.g. Graphs)")
+	Command.Flags().StringVar(&opts.companies, "companies", "", "Comma separated list of companies known to ask it (e.g. google,amazon)")
+	Command.Flags().StringVar(&opts.lists, "lists", "", "Comma separated list of curated lists it belongs to (e.g. \"Blind 75,Grind 75\")")
+	Command.Flags().StringVar(&opts.platform, "platform", "", "Platform hosting it ("+strings.Join(types.Platforms, ", ")+"), derived from the URL by default")
+	Command.Flags().BoolVar(&opts.premium, "premium", false, "The question is locked behind a paid subscription")
+	Command.Flags().StringVar(&opts.notes, "notes", "", "Personal notes for the question")
+	_ = Command.MarkFlagRequired("name")
+	_ = Command.MarkFlagRequired("url")
+	_ = Command.MarkFlagRequired("difficulty")
+
+	return Command
+}
+
+func addCmd(db db.Database, opts *questionOptions) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executeAdd(db, opts); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+func executeAdd(db db.Database, opts *questionOptions) error {
+	question := types.Question{
+		Name:           strings.TrimSpace(opts.name),
+		URL:            strings.TrimSpace(opts.url),
+		Difficulty:     strings.ToLower(strings.TrimSpace(opts.difficulty)),
+		Tags:           common.SplitCSV(opts.tags),
+		Section:        strings.TrimSpace(opts.section),
+		Companies:      common.SplitCSV(opts.companies),
+		Lists:          common.SplitCSV(opts.lists),
+		Platform:       strings.ToLower(strings.TrimSpace(opts.platform)),
+		Premium:        opts.premium,
+		Notes:          opts.notes,
+		EasinessFactor: 2.5,
+	}
+
+	if question.Platform == "" {
+		question.Platform = types.PlatformFromURL(question.URL)
+	}
+	if err := validateQuestion(question); err != nil {
+		return err
+	}
+
+	if err := db.InsertQuestions([]types.Question{question}); err != nil {
+		return fmt.Errorf("adding question: %w", err)
+	}
+
+	color.Green("Successfully added '%s'", question.Name)
+	return nil
+}
+
+func validateQuestion(question types.Question) error {
+	if question.Name == "" {
+		return fmt.Errorf("question name cannot be empty")
+	}
+	if question.URL == "" {
+		return fmt.Errorf("question url cannot be empty")
+	}
+	if !types.IsValidDifficulty(question.Difficulty) {
+		return fmt.Errorf("invalid difficulty %q, expected one of %s", question.Difficulty, strings.Join(types.Difficulties, ", "))
+	}
+	if !types.IsValidPlatform(question.Platform) {
+		return fmt.Errorf("invalid platform %q, expected one of %s", question.Platform, strings.Join(types.Platforms, ", "))
+	}
+	return nil
+}
+
+func parseQuestionID(arg string) (uint, error) {
+	id, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid question id %q", arg)
+	}
+	return uint(id), nil
+}
+
+func findQuestion(db db.Database, id uint) (types.Question, error) {
+	question, err := db.FindQuestionByID(id)
+	if err != nil {
+		return types.Question{}, fmt.Errorf("question with ID %d not found", id)
+	}
+	return question, nil
+}
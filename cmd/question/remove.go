@@ -0,0 +1,65 @@
+package question
+
+import (
+	"dsacli/common"
+	"dsacli/db"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var force = false
+
+func getRemoveCommand(db db.Database) *cobra.Command {
+	Command := &cobra.Command{
+		Use:   "rm [question_id]",
+		Short: "Remove a question from the bank",
+		Long:  `Permanently remove a question along with its review history and any plans it appears in. Use 'question archive' to keep the history instead.`,
+		Args:  cobra.ExactArgs(1),
+		Run:   removeCmd(db),
+	}
+
+	Command.Flags().BoolVarP(&force, "force", "f", false, "Remove without asking for confirmation")
+
+	return Command
+}
+
+func removeCmd(db db.Database) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executeRemove(db, args); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+func executeRemove(db db.Database, args []string) error {
+	id, err := parseQuestionID(args[0])
+	if err != nil {
+		return err
+	}
+
+	question, err := findQuestion(db, id)
+	if err != nil {
+		return err
+	}
+
+	if !force {
+		confirmed, err := common.PromptConfirm(fmt.Sprintf("Remove '%s' (ID: %d) and all of its history", question.Name, question.ID))
+		if err != nil {
+			return fmt.Errorf("reading confirmation: %w", err)
+		}
+		if !confirmed {
+			color.Yellow("Aborted, nothing was removed.")
+			return nil
+		}
+	}
+
+	if err := db.DeleteQuestion(id); err != nil {
+		return fmt.Errorf("removing question: %w", err)
+	}
+
+	color.Green("Successfully removed '%s' (ID: %d)", question.Name, question.ID)
+	return nil
+}
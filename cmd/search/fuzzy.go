@@ -0,0 +1,102 @@
+package search
+
+import (
+	"dsacli/types"
+	"sort"
+	"strings"
+)
+
+// Field weights, a match in the name is worth more than a match in the notes
+const (
+	nameWeight  = 3.0
+	tagWeight   = 2.0
+	urlWeight   = 1.0
+	notesWeight = 1.0
+)
+
+type Result struct {
+	Question types.Question
+	Score    float64
+}
+
+// fuzzyScore scores how well term matches text. A substring match scores
+// higher the earlier it appears and the more of the text it covers, a
+// subsequence match scores lower the more spread out its characters are
+// and no match scores 0.
+func fuzzyScore(term, text string) float64 {
+	term = strings.ToLower(term)
+	text = strings.ToLower(text)
+	if term == "" || text == "" {
+		return 0
+	}
+
+	if idx := strings.Index(text, term); idx >= 0 {
+		score := 1.0 - float64(idx)/float64(len(text))*0.25 + float64(len(term))/float64(len(text))*0.5
+		if idx == 0 || !isAlphaNumeric(text[idx-1]) {
+			score += 0.5 // match at a word boundary
+		}
+		return score
+	}
+
+	// Subsequence match: every character of term appears in order
+	termIdx, first, last := 0, -1, -1
+	for i := 0; i < len(text) && termIdx < len(term); i++ {
+		if text[i] == term[termIdx] {
+			if first < 0 {
+				first = i
+			}
+			last = i
+			termIdx++
+		}
+	}
+	if termIdx < len(term) {
+		return 0
+	}
+
+	span := float64(last - first + 1)
+	return float64(len(term)) / span * 0.5
+}
+
+// scoreQuestion returns the sum of the best field score for every term in the query.
+// If any term matches no field, the question does not match and 0 is returned.
+func scoreQuestion(terms []string, q types.Question) float64 {
+	total := 0.0
+	for _, term := range terms {
+		best := fuzzyScore(term, q.Name) * nameWeight
+		for _, tag := range q.Tags {
+			best = max(best, fuzzyScore(term, tag)*tagWeight)
+		}
+		best = max(best, fuzzyScore(term, q.URL)*urlWeight)
+		best = max(best, fuzzyScore(term, q.Notes)*notesWeight)
+
+		if best == 0 {
+			return 0
+		}
+		total += best
+	}
+	return total
+}
+
+// Rank returns the questions matching the query, best match first
+func Rank(query string, questions []types.Question) []Result {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var results []Result
+	for _, q := range questions {
+		if score := scoreQuestion(terms, q); score > 0 {
+			results = append(results, Result{Question: q, Score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+func isAlphaNumeric(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+}
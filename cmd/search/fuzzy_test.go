@@ -0,0 +1,83 @@
+package search
+
+import (
+	"dsacli/types"
+	"testing"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		term     string
+		text     string
+		expected bool
+	}{
+		{"Exact match", "two sum", "Two Sum", true},
+		{"Substring", "sum", "Two Sum", true},
+		{"Subsequence", "tsm", "Two Sum", true},
+		{"No match", "graph", "Two Sum", false},
+		{"Empty text", "sum", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := fuzzyScore(tt.term, tt.text)
+			if (score > 0) != tt.expected {
+				t.Errorf("fuzzyScore(%q, %q) = %f, want match = %v", tt.term, tt.text, score, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Substring beats subsequence", func(t *testing.T) {
+		if fuzzyScore("sum", "Two Sum") <= fuzzyScore("tsm", "Two Sum") {
+			t.Error("Expected substring match to score higher than subsequence match")
+		}
+	})
+
+	t.Run("Closer match beats longer text", func(t *testing.T) {
+		if fuzzyScore("sort", "Merge Sort") <= fuzzyScore("sort", "Merge K Sorted Linked Lists") {
+			t.Error("Expected the shorter text to score higher")
+		}
+	})
+
+	t.Run("Word boundary beats mid-word", func(t *testing.T) {
+		if fuzzyScore("sort", "Merge Sort") <= fuzzyScore("sort", "Resorting") {
+			t.Error("Expected word boundary match to score higher")
+		}
+	})
+}
+
+func TestRank(t *testing.T) {
+	questions := []types.Question{
+		{ID: 1, Name: "Two Sum", URL: "https://leetcode.com/problems/two-sum", Tags: []string{"arrays", "hashing"}},
+		{ID: 2, Name: "Number of Islands", URL: "https://leetcode.com/problems/number-of-islands", Tags: []string{"graphs", "bfs"}},
+		{ID: 3, Name: "Clone Graph", URL: "https://leetcode.com/problems/clone-graph", Tags: []string{"graphs"}, Notes: "use a hash map of visited nodes"},
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		expectedIDs []uint
+	}{
+		{"Empty query", "", nil},
+		{"Name match", "islands", []uint{2}},
+		{"Name ranks above tags", "graph", []uint{3, 2}},
+		{"Notes match", "visited", []uint{3}},
+		{"All terms must match", "graphs hashing", nil},
+		{"Terms across fields", "graph hash", []uint{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := Rank(tt.query, questions)
+			if len(results) != len(tt.expectedIDs) {
+				t.Fatalf("Rank(%q) returned %d results, want %d", tt.query, len(results), len(tt.expectedIDs))
+			}
+			for i, id := range tt.expectedIDs {
+				if results[i].Question.ID != id {
+					t.Errorf("Rank(%q)[%d] = %d, want %d", tt.query, i, results[i].Question.ID, id)
+				}
+			}
+		})
+	}
+}
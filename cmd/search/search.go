@@ -0,0 +1,73 @@
+package search
+
+import (
+	"dsacli/db"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	limit           = 20
+	includeArchived = false
+)
+
+func GetCommand(db db.Database) *cobra.Command {
+	Command := &cobra.Command{
+		Use:   "search [query]",
+		Short: "Fuzzy search the question bank",
+		Long:  `Fuzzy search questions by name, URL, tags and notes. Every word of the query has to match.`,
+		Args:  cobra.MinimumNArgs(1),
+		Run:   searchCmd(db),
+	}
+
+	Command.Flags().IntVarP(&limit, "limit", "n", 20, "Maximum number of results to show")
+	Command.Flags().BoolVar(&includeArchived, "archived", false, "Include archived questions in the results")
+
+	return Command
+}
+
+func searchCmd(db db.Database) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executeSearch(db, strings.Join(args, " ")); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+func executeSearch(database db.Database, query string) error {
+	questions, err := database.FilterQuestions(db.QuestionFilter{IncludeArchived: includeArchived, IncludeSuspended: true, IncludeExcluded: true})
+	if err != nil {
+		return fmt.Errorf("loading questions: %w", err)
+	}
+
+	results := Rank(query, questions)
+	if len(results) == 0 {
+		color.Yellow("No questions match '%s'", query)
+		return nil
+	}
+
+	color.Cyan("Questions matching '%s' (%d):", query, len(results))
+	for idx, r := range results {
+		if limit > 0 && idx >= limit {
+			color.White("  ... %d more, use --limit to see them", len(results)-limit)
+			break
+		}
+
+		q := r.Question
+		status := "❌"
+		if q.Attempted {
+			status = "✅"
+		}
+		tags := ""
+		if len(q.Tags) > 0 {
+			tags = " #" + strings.Join(q.Tags, " #")
+		}
+		fmt.Printf("  %s ID:%d - %s [%s]%s\n", status, q.ID, q.Name, q.Difficulty, tags)
+	}
+
+	return nil
+}
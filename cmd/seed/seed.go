@@ -4,19 +4,40 @@ import (
 	"dsacli/db"
 	"dsacli/types"
 	"encoding/json"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
+// seedQuestion is a question of the seed file, which may name the questions to attempt before it
+// and include its problem statement as markdown
+type seedQuestion struct {
+	types.Question
+	Prerequisites []string `json:"prerequisites,omitempty"`
+	Statement     string   `json:"statement,omitempty"`
+	Examples      string   `json:"examples,omitempty"`
+	Constraints   string   `json:"constraints,omitempty"`
+}
+
 func GetCommand(db db.Database) *cobra.Command {
 	return &cobra.Command{
-		Use:   "seed [json_path]",
+		Use:   "seed [json_path | markdown_dir]",
 		Short: "Add problems to database",
-		Long:  "Use this command to add problems to the database",
-		Run:   runSeed(db),
-		Args:  cobra.ExactArgs(1),
+		Long: `Use this command to add problems to the database.
+A problem can list the names of the problems to attempt before it in "prerequisites", they are
+looked up in the file first and then in the database. Its "platform" is derived from the URL
+unless given, and "premium": true marks a problem locked behind a paid subscription. Its
+"statement", "examples" and "constraints" are stored as markdown to practice offline.
+
+Given a directory, the statements of problems already in the database are imported from the
+markdown files in it instead. Each file is named after the slug of a problem, the last part of
+its URL (e.g. two-sum.md for https://leetcode.com/problems/two-sum), and its "Examples" and
+"Constraints" headings start those parts.`,
+		Run:  runSeed(db),
+		Args: cobra.ExactArgs(1),
 	}
 }
 func runSeed(db db.Database) func(cmd *cobra.Command, args []string) {
@@ -27,32 +48,102 @@ func runSeed(db db.Database) func(cmd *cobra.Command, args []string) {
 
 func executeSeed(db db.Database, args []string) {
 	problemFilePath := args[0]
-	questions, err := readQuestions(problemFilePath)
+	if info, err := os.Stat(problemFilePath); err == nil && info.IsDir() {
+		executeImportStatements(db, problemFilePath)
+		return
+	}
+	seeds, err := readQuestions(problemFilePath)
 	if err != nil {
 		color.Red("Error reading questions from file: %s", err)
 		return
 	}
 
+	existing, err := db.GetAllQuestions()
+	if err != nil {
+		color.Red("Error loading questions from database: %s", err)
+		return
+	}
+	dependencies, err := db.GetDependencies()
+	if err != nil {
+		color.Red("Error loading prerequisites from database: %s", err)
+		return
+	}
+	graph, err := resolvePrerequisites(seeds, existing, dependencies)
+	if err != nil {
+		color.Red("Error in prerequisites: %s", err)
+		return
+	}
+
+	questions := make([]types.Question, len(seeds))
+	for i, seed := range seeds {
+		questions[i] = seed.Question
+		if questions[i].Platform, err = platform(seed.Question); err != nil {
+			color.Red("Error in '%s': %s", seed.Name, err)
+			return
+		}
+	}
+
 	color.Yellow("Inserting %d questions into database", len(questions))
 
-	if err := db.InsertQuestions(questions); err != nil {
-		color.Red("Error inserting questions into database: %s", err)
+	var added []types.QuestionDependency
+	var contents []types.QuestionContent
+	err = db.SeedQuestions(questions, func(inserted []types.Question) ([]types.QuestionDependency, []types.QuestionContent) {
+		added, contents = graph.dependencies(inserted), statements(seeds, inserted)
+		return added, contents
+	})
+	if err != nil {
+		color.Red("Error seeding the database, nothing was added: %s", err)
 		return
 	}
 
 	color.Green("Successfully seeded %d questions into the database", len(questions))
+	if len(added) > 0 {
+		color.Green("Added %d prerequisites", len(added))
+	}
+	if len(contents) > 0 {
+		color.Green("Added %d statements", len(contents))
+	}
+}
+
+// statements returns the statements included in the seed file for the inserted questions
+func statements(seeds []seedQuestion, inserted []types.Question) []types.QuestionContent {
+	var contents []types.QuestionContent
+	for i, seed := range seeds {
+		if strings.TrimSpace(seed.Statement) == "" {
+			continue
+		}
+		contents = append(contents, types.QuestionContent{
+			QuestionID:  inserted[i].ID,
+			Statement:   strings.TrimSpace(seed.Statement),
+			Examples:    strings.TrimSpace(seed.Examples),
+			Constraints: strings.TrimSpace(seed.Constraints),
+		})
+	}
+	return contents
+}
+
+// platform returns the platform of a seeded question, derived from its URL unless the seed file names one
+func platform(q types.Question) (string, error) {
+	if q.Platform == "" {
+		return types.PlatformFromURL(q.URL), nil
+	}
+	platform := strings.ToLower(strings.TrimSpace(q.Platform))
+	if !types.IsValidPlatform(platform) {
+		return "", fmt.Errorf("unknown platform %q, expected one of %s", q.Platform, strings.Join(types.Platforms, ", "))
+	}
+	return platform, nil
 }
 
-func readQuestions(path string) ([]types.Question, error) {
+func readQuestions(path string) ([]seedQuestion, error) {
 	file, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			color.Red("Unable to find file in path %s", path)
-			return nil, err
 		}
+		return nil, err
 	}
 
-	var questions []types.Question
+	var questions []seedQuestion
 	if err := json.Unmarshal(file, &questions); err != nil {
 		color.Red("Unable to read questions from file with error: %s", err)
 		return nil, err
@@ -60,3 +151,136 @@ func readQuestions(path string) ([]types.Question, error) {
 
 	return questions, nil
 }
+
+// node is a question in the prerequisite graph being seeded: a question of the seed file by
+// index, or a question already in the database by ID
+type node struct {
+	seeded bool
+	index  int
+	id     uint
+}
+
+// prerequisiteGraph is the prerequisites of the seed file resolved to questions
+type prerequisiteGraph struct {
+	edges map[node][]node // question to its prerequisites
+	names map[node]string
+}
+
+// resolvePrerequisites looks up the prerequisite names of the seed file, in the file first and
+// in the database otherwise, and checks that they don't form a cycle with the prerequisites
+// already in the database
+func resolvePrerequisites(seeds []seedQuestion, existing []types.Question, dependencies []types.QuestionDependency) (prerequisiteGraph, error) {
+	graph := prerequisiteGraph{edges: make(map[node][]node), names: make(map[node]string)}
+	seededByName := make(map[string][]node)
+	existingByName := make(map[string][]node)
+	for i, seed := range seeds {
+		n := node{seeded: true, index: i}
+		graph.names[n] = seed.Name
+		seededByName[nameKey(seed.Name)] = append(seededByName[nameKey(seed.Name)], n)
+	}
+	for _, q := range existing {
+		n := node{id: q.ID}
+		graph.names[n] = q.Name
+		existingByName[nameKey(q.Name)] = append(existingByName[nameKey(q.Name)], n)
+	}
+	for _, d := range dependencies {
+		from := node{id: d.QuestionID}
+		graph.edges[from] = append(graph.edges[from], node{id: d.PrerequisiteID})
+	}
+
+	for i, seed := range seeds {
+		from := node{seeded: true, index: i}
+		for _, name := range seed.Prerequisites {
+			matches := seededByName[nameKey(name)]
+			if len(matches) == 0 {
+				matches = existingByName[nameKey(name)]
+			}
+			switch {
+			case len(matches) == 0:
+				return prerequisiteGraph{}, fmt.Errorf("%q: unknown prerequisite %q", seed.Name, name)
+			case len(matches) > 1:
+				return prerequisiteGraph{}, fmt.Errorf("%q: %d questions are named %q", seed.Name, len(matches), name)
+			case matches[0] == from:
+				return prerequisiteGraph{}, fmt.Errorf("%q can't be its own prerequisite", seed.Name)
+			}
+			graph.edges[from] = append(graph.edges[from], matches[0])
+		}
+	}
+
+	if cycle := graph.findCycle(); len(cycle) > 0 {
+		names := make([]string, len(cycle))
+		for i, n := range cycle {
+			names[i] = graph.names[n]
+		}
+		return prerequisiteGraph{}, fmt.Errorf("prerequisites form a cycle: %s", strings.Join(names, " -> "))
+	}
+	return graph, nil
+}
+
+// findCycle returns a path of questions leading back to its first one, if the graph has any
+func (g prerequisiteGraph) findCycle() []node {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[node]int)
+	var path []node
+
+	var visit func(n node) []node
+	visit = func(n node) []node {
+		state[n] = visiting
+		path = append(path, n)
+		for _, next := range g.edges[n] {
+			switch state[next] {
+			case visiting:
+				for i, p := range path {
+					if p == next {
+						return append(append([]node(nil), path[i:]...), next)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[n] = done
+		return nil
+	}
+
+	for n := range g.edges {
+		if state[n] == unvisited {
+			if cycle := visit(n); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// dependencies returns the prerequisites of the seeded questions, once they were inserted
+func (g prerequisiteGraph) dependencies(inserted []types.Question) []types.QuestionDependency {
+	id := func(n node) uint {
+		if n.seeded {
+			return inserted[n.index].ID
+		}
+		return n.id
+	}
+
+	var dependencies []types.QuestionDependency
+	for from, prereqs := range g.edges {
+		if !from.seeded {
+			continue
+		}
+		for _, to := range prereqs {
+			dependencies = append(dependencies, types.QuestionDependency{QuestionID: id(from), PrerequisiteID: id(to)})
+		}
+	}
+	return dependencies
+}
+
+func nameKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,218 @@
+package seed
+
+import (
+	"dsacli/config"
+	"dsacli/db"
+	"dsacli/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestResolvePrerequisites(t *testing.T) {
+	existing := []types.Question{{ID: 1, Name: "Matrix DFS"}, {ID: 2, Name: "Two Sum"}, {ID: 3, Name: "Islands"}}
+	dependencies := []types.QuestionDependency{{QuestionID: 3, PrerequisiteID: 1}}
+	seed := func(name string, prereqs ...string) seedQuestion {
+		return seedQuestion{Question: types.Question{Name: name}, Prerequisites: prereqs}
+	}
+
+	tests := []struct {
+		name    string
+		seeds   []seedQuestion
+		want    []types.QuestionDependency
+		wantErr string
+	}{
+		{
+			name:  "Prerequisite in the database",
+			seeds: []seedQuestion{seed("Matrix BFS", "matrix dfs")},
+			want:  []types.QuestionDependency{{QuestionID: 10, PrerequisiteID: 1}},
+		},
+		{
+			name:  "Prerequisite in the file wins",
+			seeds: []seedQuestion{seed("Matrix BFS", "Matrix DFS"), seed("Matrix DFS")},
+			want:  []types.QuestionDependency{{QuestionID: 10, PrerequisiteID: 11}},
+		},
+		{
+			name:    "Unknown prerequisite",
+			seeds:   []seedQuestion{seed("Matrix BFS", "Matrix Walk")},
+			wantErr: "unknown prerequisite",
+		},
+		{
+			name:    "Own prerequisite",
+			seeds:   []seedQuestion{seed("Matrix BFS", "Matrix BFS")},
+			wantErr: "its own prerequisite",
+		},
+		{
+			name:    "Cycle within the file",
+			seeds:   []seedQuestion{seed("A", "B"), seed("B", "C"), seed("C", "A")},
+			wantErr: "cycle",
+		},
+		{
+			name:    "Ambiguous name",
+			seeds:   []seedQuestion{seed("A", "B"), seed("B"), seed("B")},
+			wantErr: "2 questions are named",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph, err := resolvePrerequisites(tt.seeds, existing, dependencies)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("resolvePrerequisites() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolvePrerequisites() unexpected error: %v", err)
+			}
+
+			// Pretend the seeded questions got IDs from 10 on
+			inserted := make([]types.Question, len(tt.seeds))
+			for i := range inserted {
+				inserted[i].ID = uint(10 + i)
+			}
+			got := graph.dependencies(inserted)
+			sort.Slice(got, func(i, j int) bool { return got[i].QuestionID < got[j].QuestionID })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dependencies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlatform(t *testing.T) {
+	tests := []struct {
+		name     string
+		question types.Question
+		want     string
+		wantErr  bool
+	}{
+		{name: "LeetCode", question: types.Question{URL: "https://leetcode.com/problems/two-sum"}, want: types.PlatformLeetCode},
+		{name: "LeetCode China with www", question: types.Question{URL: "https://www.leetcode.cn/problems/two-sum"}, want: types.PlatformLeetCode},
+		{name: "NeetCode", question: types.Question{URL: "https://neetcode.io/problems/matrixBFS"}, want: types.PlatformNeetCode},
+		{name: "HackerRank", question: types.Question{URL: "https://www.hackerrank.com/challenges/ctci-array-left-rotation"}, want: types.PlatformHackerRank},
+		{name: "Codeforces", question: types.Question{URL: "https://codeforces.com/problemset/problem/4/A"}, want: types.PlatformCodeforces},
+		{name: "Unknown site", question: types.Question{URL: "https://example.com/q1"}, want: types.PlatformCustom},
+		{name: "Not a URL", question: types.Question{URL: "two sum"}, want: types.PlatformCustom},
+		{name: "Given platform wins", question: types.Question{URL: "https://example.com/q1", Platform: " LeetCode "}, want: types.PlatformLeetCode},
+		{name: "Unknown given platform", question: types.Question{URL: "https://leetcode.com/problems/a", Platform: "topcoder"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := platform(tt.question)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("platform() expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("platform() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("platform() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStatement(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     types.QuestionContent
+	}{
+		{
+			name:     "Statement only",
+			markdown: "Return the indices of the two numbers adding up to target.\n",
+			want:     types.QuestionContent{Statement: "Return the indices of the two numbers adding up to target."},
+		},
+		{
+			name:     "All parts",
+			markdown: "# Two Sum\n\nFind two numbers.\n\n## Example 1\n\n```\nnums = [2,7], target = 9\n```\n\n### Constraints:\r\n\n- 2 <= n\n",
+			want: types.QuestionContent{
+				Statement:   "# Two Sum\n\nFind two numbers.",
+				Examples:    "## Example 1\n\n```\nnums = [2,7], target = 9\n```",
+				Constraints: "- 2 <= n",
+			},
+		},
+		{
+			name:     "Other headings stay in their part",
+			markdown: "Find two numbers.\n\n## Follow up\n\nIn O(n)?\n\n## Examples\n\n1 2",
+			want:     types.QuestionContent{Statement: "Find two numbers.\n\n## Follow up\n\nIn O(n)?", Examples: "1 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseStatement(tt.markdown); got != tt.want {
+				t.Errorf("parseStatement() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchStatements(t *testing.T) {
+	questions := []types.Question{
+		{ID: 1, URL: "https://leetcode.com/problems/two-sum/description/"},
+		{ID: 2, URL: "https://neetcode.io/problems/matrixBFS"},
+		{ID: 3, URL: "https://example.com/questions/valid-anagram"},
+	}
+	files := map[string]types.QuestionContent{
+		"two-sum":       {Statement: "a"},
+		"matrixbfs":     {Statement: "b"},
+		"valid-anagram": {Statement: "c"},
+		"three-sum":     {Statement: "d"},
+	}
+
+	contents, unknown := matchStatements(files, questions)
+	want := []types.QuestionContent{{QuestionID: 1, Statement: "a"}, {QuestionID: 2, Statement: "b"}, {QuestionID: 3, Statement: "c"}}
+	if !reflect.DeepEqual(contents, want) {
+		t.Errorf("matchStatements() = %+v, want %+v", contents, want)
+	}
+	if !reflect.DeepEqual(unknown, []string{"three-sum"}) {
+		t.Errorf("matchStatements() unknown = %v, want [three-sum]", unknown)
+	}
+}
+
+func TestExecuteSeed(t *testing.T) {
+	dir := t.TempDir()
+	database, err := db.NewSQLDatabase(config.NewConfig(filepath.Join(dir, "test.db")))
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	path := filepath.Join(dir, "questions.json")
+	seeds := `[
+		{"name": "Two Sum", "url": "https://leetcode.com/problems/two-sum", "difficulty": "easy", "statement": "Find two numbers."},
+		{"name": "3Sum", "url": "https://leetcode.com/problems/3sum", "difficulty": "medium", "prerequisites": ["Two Sum"]}
+	]`
+	if err := os.WriteFile(path, []byte(seeds), 0o644); err != nil {
+		t.Fatalf("Failed to write seed file: %v", err)
+	}
+	executeSeed(database, []string{path})
+
+	questions, err := database.GetAllQuestions()
+	if err != nil {
+		t.Fatalf("GetAllQuestions() unexpected error: %v", err)
+	}
+	if len(questions) != 2 {
+		t.Fatalf("Expected 2 seeded questions, got %d", len(questions))
+	}
+	dependencies, err := database.GetDependencies()
+	if err != nil {
+		t.Fatalf("GetDependencies() unexpected error: %v", err)
+	}
+	want := []types.QuestionDependency{{QuestionID: questions[1].ID, PrerequisiteID: questions[0].ID}}
+	if !reflect.DeepEqual(dependencies, want) {
+		t.Errorf("Expected prerequisites %+v, got %+v", want, dependencies)
+	}
+	if content, err := database.GetQuestionContent(questions[0].ID); err != nil || content.Statement != "Find two numbers." {
+		t.Errorf("Expected the statement of Two Sum to be stored, got %+v, %v", content, err)
+	}
+}
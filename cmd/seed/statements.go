@@ -0,0 +1,111 @@
+package seed
+
+import (
+	"dsacli/db"
+	"dsacli/types"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// sectionHeading matches the markdown headings that start the examples and constraints of a
+// statement, numbered ones such as "Example 2" included
+var sectionHeading = regexp.MustCompile(`(?i)^#{1,6}\s*(examples?|constraints?)(\s+\d+)?\s*:?\s*$`)
+
+func executeImportStatements(db db.Database, dir string) {
+	files, err := readStatements(dir)
+	if err != nil {
+		color.Red("Error reading statements: %s", err)
+		return
+	}
+	questions, err := db.GetAllQuestions()
+	if err != nil {
+		color.Red("Error loading questions from database: %s", err)
+		return
+	}
+
+	contents, unknown := matchStatements(files, questions)
+	if err := db.SaveQuestionContents(contents); err != nil {
+		color.Red("Error inserting statements into database: %s", err)
+		return
+	}
+
+	color.Green("Imported %d statements", len(contents))
+	if len(unknown) > 0 {
+		color.Yellow("No question found for %d file(s): %s", len(unknown), strings.Join(unknown, ", "))
+	}
+}
+
+// readStatements parses the markdown files of a directory, by slug
+func readStatements(dir string) (map[string]types.QuestionContent, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no markdown files in %s", dir)
+	}
+
+	files := make(map[string]types.QuestionContent, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		files[strings.TrimSuffix(filepath.Base(path), ".md")] = parseStatement(string(data))
+	}
+	return files, nil
+}
+
+// parseStatement splits a markdown statement into the statement itself, its examples and its
+// constraints, each of the latter starting at its heading. The headings of numbered examples are
+// kept to tell them apart.
+func parseStatement(markdown string) types.QuestionContent {
+	parts := map[string][]string{}
+	part := "statement"
+	for _, line := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n") {
+		if m := sectionHeading.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			part = strings.TrimSuffix(strings.ToLower(m[1]), "s")
+			if m[2] == "" {
+				continue
+			}
+		}
+		parts[part] = append(parts[part], line)
+	}
+
+	join := func(part string) string {
+		return strings.TrimSpace(strings.Join(parts[part], "\n"))
+	}
+	return types.QuestionContent{Statement: join("statement"), Examples: join("example"), Constraints: join("constraint")}
+}
+
+// matchStatements assigns the statements to the questions with the same slug, ignoring case,
+// and returns the slugs no question has in alphabetical order
+func matchStatements(files map[string]types.QuestionContent, questions []types.Question) ([]types.QuestionContent, []string) {
+	bySlug := make(map[string]uint, len(questions))
+	for _, q := range questions {
+		if slug := strings.ToLower(q.Slug()); slug != "" {
+			bySlug[slug] = q.ID
+		}
+	}
+
+	var contents []types.QuestionContent
+	var unknown []string
+	for slug, content := range files {
+		id, ok := bySlug[strings.ToLower(slug)]
+		if !ok {
+			unknown = append(unknown, slug)
+			continue
+		}
+		content.QuestionID = id
+		contents = append(contents, content)
+	}
+	sort.Slice(contents, func(i, j int) bool { return contents[i].QuestionID < contents[j].QuestionID })
+	sort.Strings(unknown)
+	return contents, unknown
+}
@@ -0,0 +1,124 @@
+package serve
+
+import (
+	"dsacli/api"
+	"dsacli/config"
+	"dsacli/practice"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+//go:embed web
+var webFiles embed.FS
+
+var (
+	addr        = "127.0.0.1:8080"
+	allowRemote bool
+)
+
+func GetCommand(service *practice.Service, cfg config.Config) *cobra.Command {
+	Command := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve a local web dashboard and API",
+		Long: `Serve a local web dashboard to view and complete today's questions, browse the question bank and see your progress charts.
+
+The JSON API used by the dashboard is served under /api/v1 and documented at /api/v1/openapi.yaml.
+It requires the token printed on startup (stored in ~/.dsacli/api_token) as an "Authorization: Bearer <token>" header,
+the dashboard asks for it once and keeps it in the browser.
+
+Only loopback addresses are accepted unless --allow-remote is passed, the token is all that protects your data.`,
+		Example: `  dsacli serve --addr 127.0.0.1:9000
+  dsacli serve --addr 0.0.0.0:8080 --allow-remote   # reachable from other machines`,
+		Run: serveCmd(service, cfg),
+	}
+
+	Command.Flags().StringVar(&addr, "addr", "127.0.0.1:8080", "Address to listen on")
+	Command.Flags().BoolVar(&allowRemote, "allow-remote", false, "Allow listening on an address reachable from other machines")
+
+	return Command
+}
+
+func serveCmd(service *practice.Service, cfg config.Config) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executeServe(service, cfg); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+func executeServe(service *practice.Service, cfg config.Config) error {
+	if err := checkAddr(addr, allowRemote); err != nil {
+		return err
+	}
+
+	token, err := api.LoadOrCreateToken(cfg.APITokenPath)
+	if err != nil {
+		return err
+	}
+
+	handler, err := newHandler(service, token)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	color.Cyan("Serving dashboard on http://%s", displayAddr(addr))
+	color.Cyan("API available at http://%s/api/v1 (token: %s)", displayAddr(addr), token)
+	color.Yellow("Press Ctrl+C to stop")
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// newHandler builds the routes for the embedded web UI and the JSON API
+func newHandler(service *practice.Service, token string) (http.Handler, error) {
+	static, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/v1/", http.StripPrefix("/api/v1", api.NewHandler(service, token)))
+	mux.Handle("/", http.FileServerFS(static))
+
+	return mux, nil
+}
+
+// checkAddr refuses addresses reachable from other machines unless allowRemote is set
+func checkAddr(addr string, allowRemote bool) error {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid address %q, use e.g. 127.0.0.1:8080: %w", addr, err)
+	}
+	if allowRemote || host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	local := net.JoinHostPort("127.0.0.1", port)
+	return fmt.Errorf("refusing to listen on %s, which other machines can reach: use %s to keep it local, or pass --allow-remote to do so anyway", addr, local)
+}
+
+// displayAddr turns a listen address such as ":8080" into one that can be opened in a browser
+func displayAddr(addr string) string {
+	if len(addr) > 0 && addr[0] == ':' {
+		return "localhost" + addr
+	}
+	return addr
+}
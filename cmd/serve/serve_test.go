@@ -0,0 +1,99 @@
+package serve
+
+import (
+	"dsacli/config"
+	"dsacli/db"
+	"dsacli/practice"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewHandler(t *testing.T) {
+	cfg := config.NewConfig(filepath.Join(t.TempDir(), "test.db"))
+	database, err := db.NewSQLDatabase(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	handler, err := newHandler(practice.NewService(database, cfg), "secret")
+	if err != nil {
+		t.Fatalf("newHandler() unexpected error: %v", err)
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"Index without the token", "/", http.StatusOK, `id="token-dialog"`},
+		{"Static assets", "/app.js", http.StatusOK, "API_BASE"},
+		{"API requires token", "/api/v1/today", http.StatusUnauthorized, "unauthorized"},
+		{"OpenAPI document", "/api/v1/openapi.yaml", http.StatusOK, "openapi: 3.0.3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(server.URL + tt.path)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if !strings.Contains(string(body), tt.expectedBody) {
+				t.Errorf("Expected body to contain %q", tt.expectedBody)
+			}
+			if strings.Contains(string(body), "secret") {
+				t.Errorf("Expected the token not to be served")
+			}
+		})
+	}
+}
+
+func TestDisplayAddr(t *testing.T) {
+	tests := map[string]string{
+		":8080":          "localhost:8080",
+		"127.0.0.1:8080": "127.0.0.1:8080",
+	}
+	for addr, expected := range tests {
+		if got := displayAddr(addr); got != expected {
+			t.Errorf("displayAddr(%q) = %q, want %q", addr, got, expected)
+		}
+	}
+}
+
+func TestCheckAddr(t *testing.T) {
+	tests := []struct {
+		addr        string
+		allowRemote bool
+		expectedErr bool
+	}{
+		{"127.0.0.1:8080", false, false},
+		{"localhost:8080", false, false},
+		{"[::1]:8080", false, false},
+		{":8080", false, true},
+		{"0.0.0.0:8080", false, true},
+		{"192.168.1.10:8080", false, true},
+		{"0.0.0.0:8080", true, false},
+		{"8080", false, true},
+	}
+	for _, tt := range tests {
+		if err := checkAddr(tt.addr, tt.allowRemote); (err != nil) != tt.expectedErr {
+			t.Errorf("checkAddr(%q, %v) error = %v, want error %v", tt.addr, tt.allowRemote, err, tt.expectedErr)
+		}
+	}
+
+	if err := checkAddr(":8080", false); err == nil || !strings.Contains(err.Error(), "127.0.0.1:8080") {
+		t.Errorf("checkAddr(\":8080\") error = %v, want it to suggest 127.0.0.1:8080", err)
+	}
+}
@@ -0,0 +1,314 @@
+"use strict";
+
+const SVG_NS = "http://www.w3.org/2000/svg";
+const API_BASE = "/api/v1";
+const TOKEN_KEY = "dsacli-api-token";
+
+async function api(path, options = {}) {
+  const response = await fetch(API_BASE + path, {
+    headers: {
+      "Content-Type": "application/json",
+      "Authorization": `Bearer ${localStorage.getItem(TOKEN_KEY) || ""}`,
+    },
+    ...options,
+  });
+  const body = await response.json();
+  if (response.status === 401) {
+    askToken();
+  }
+  if (!response.ok) {
+    throw new Error(body.error ? body.error.message : response.statusText);
+  }
+  return body;
+}
+
+function el(tag, attrs = {}, ...children) {
+  const node = document.createElement(tag);
+  for (const [key, value] of Object.entries(attrs)) {
+    if (key === "class") node.className = value;
+    else if (key.startsWith("on")) node.addEventListener(key.slice(2), value);
+    else node.setAttribute(key, value);
+  }
+  for (const child of children) {
+    node.append(child);
+  }
+  return node;
+}
+
+function svg(tag, attrs = {}) {
+  const node = document.createElementNS(SVG_NS, tag);
+  for (const [key, value] of Object.entries(attrs)) {
+    node.setAttribute(key, value);
+  }
+  return node;
+}
+
+function difficultyBadge(difficulty) {
+  return el("span", { class: `difficulty ${difficulty}` }, difficulty);
+}
+
+// Tabs
+
+for (const tab of document.querySelectorAll(".tab")) {
+  tab.addEventListener("click", () => {
+    document.querySelectorAll(".tab").forEach((t) => t.classList.toggle("active", t === tab));
+    document.querySelectorAll(".view").forEach((v) => v.classList.toggle("active", v.id === tab.dataset.view));
+    loaders[tab.dataset.view]();
+  });
+}
+
+// Today
+
+let completing = null;
+
+async function loadToday() {
+  const list = document.getElementById("today-list");
+  const summary = document.getElementById("today-summary");
+  list.replaceChildren();
+
+  try {
+    const plan = await api("/today");
+    const done = plan.filter((item) => item.completed).length;
+    summary.textContent = plan.length === 0
+      ? "No questions found. Seed the question bank first."
+      : `You have completed ${done} out of ${plan.length} questions for today.`;
+
+    for (const item of plan) {
+      const q = item.question;
+      const action = item.completed
+        ? el("span", { class: "muted" }, "Completed ✓")
+        : el("button", { class: "primary", onclick: () => openCompleteDialog(q) }, "Complete");
+      list.append(el("li", { class: `card ${item.completed ? "completed" : ""}` },
+        el("div", {}, el("a", { href: q.url, target: "_blank", rel: "noopener" }, q.name), difficultyBadge(q.difficulty)),
+        el("div", {}, statementButton(q), action)));
+    }
+  } catch (err) {
+    summary.textContent = `Error: ${err.message}`;
+  }
+}
+
+const dialog = document.getElementById("complete-dialog");
+const form = document.getElementById("complete-form");
+
+for (const name of ["hints_needed", "time_taken", "optimal_solution", "any_bugs"]) {
+  const input = form.elements[name];
+  const output = document.getElementById({
+    hints_needed: "hints-value",
+    time_taken: "time-value",
+    optimal_solution: "optimality-value",
+    any_bugs: "bugs-value",
+  }[name]);
+  input.addEventListener("input", () => { output.textContent = input.value; });
+}
+
+form.elements.unsolved.addEventListener("change", (event) => {
+  form.elements.time_taken.disabled = event.target.checked;
+});
+
+function openCompleteDialog(question) {
+  completing = question;
+  form.reset();
+  form.elements.time_taken.disabled = false;
+  form.querySelectorAll("output").forEach((output) => {
+    output.textContent = output.parentElement.querySelector("input").value;
+  });
+  document.getElementById("complete-title").textContent = question.name;
+  document.getElementById("complete-error").textContent = "";
+  dialog.showModal();
+}
+
+document.getElementById("complete-cancel").addEventListener("click", () => dialog.close());
+
+form.addEventListener("submit", async (event) => {
+  event.preventDefault();
+  const feedback = {
+    hints_needed: Number(form.elements.hints_needed.value),
+    time_taken: form.elements.unsolved.checked ? -1 : Number(form.elements.time_taken.value),
+    optimal_solution: Number(form.elements.optimal_solution.value),
+    any_bugs: Number(form.elements.any_bugs.value),
+  };
+
+  try {
+    await api(`/questions/${completing.id}/attempts`, { method: "POST", body: JSON.stringify(feedback) });
+    dialog.close();
+    loadToday();
+  } catch (err) {
+    document.getElementById("complete-error").textContent = err.message;
+  }
+});
+
+// Statements
+
+const statementDialog = document.getElementById("statement-dialog");
+
+function statementButton(question) {
+  return el("button", { class: "small", onclick: () => openStatement(question) }, "Statement");
+}
+
+async function openStatement(question) {
+  const body = document.getElementById("statement-body");
+  document.getElementById("statement-title").textContent = question.name;
+  body.replaceChildren(el("p", { class: "muted" }, "Loading..."));
+  statementDialog.showModal();
+
+  try {
+    const content = await api(`/questions/${question.id}/statement`);
+    body.replaceChildren(el("pre", {}, content.statement));
+    for (const [title, text] of [["Examples", content.examples], ["Constraints", content.constraints]]) {
+      if (text) body.append(el("h4", {}, title), el("pre", {}, text));
+    }
+  } catch (err) {
+    body.replaceChildren(el("p", { class: "error" }, err.message));
+  }
+}
+
+document.getElementById("statement-close").addEventListener("click", () => statementDialog.close());
+
+// Question bank
+
+async function loadQuestions() {
+  const params = new URLSearchParams();
+  const difficulty = document.getElementById("filter-difficulty").value;
+  const tag = document.getElementById("filter-tag").value.trim();
+  const sort = document.getElementById("filter-sort").value;
+  if (difficulty) params.set("difficulty", difficulty);
+  if (tag) params.set("tag", tag);
+  if (sort) params.set("sort", sort);
+  if (document.getElementById("filter-due").checked) params.set("due", "true");
+
+  const rows = document.getElementById("question-rows");
+  rows.replaceChildren();
+  try {
+    const questions = await api(`/questions?${params}`);
+    for (const q of questions) {
+      rows.append(el("tr", {},
+        el("td", {}, String(q.id)),
+        el("td", {}, el("a", { href: q.url, target: "_blank", rel: "noopener" }, q.name), statementButton(q)),
+        el("td", {}, difficultyBadge(q.difficulty)),
+        el("td", {}, String(q.attempt_count)),
+        el("td", {}, q.attempted ? q.last_p_score.toFixed(2) : "-"),
+        el("td", {}, q.mastered ? "✓" : "")));
+    }
+  } catch (err) {
+    rows.append(el("tr", {}, el("td", { colspan: "6", class: "error" }, err.message)));
+  }
+}
+
+for (const id of ["filter-difficulty", "filter-sort", "filter-due"]) {
+  document.getElementById(id).addEventListener("change", loadQuestions);
+}
+document.getElementById("filter-tag").addEventListener("input", loadQuestions);
+
+// Progress charts
+
+async function loadStats() {
+  const stats = await api("/stats");
+  renderTrend(stats.p_score_trend);
+  renderHeatmap(stats.attempts_by_date);
+}
+
+function renderTrend(points) {
+  const container = document.getElementById("trend-chart");
+  container.replaceChildren();
+  if (points.length === 0) {
+    container.append(el("p", { class: "muted" }, "Complete a few questions to see your p-score trend."));
+    return;
+  }
+
+  const width = 1000, height = 240, pad = 30;
+  const chart = svg("svg", { viewBox: `0 0 ${width} ${height}`, width: "100%" });
+  const x = (i) => pad + (points.length === 1 ? (width - 2 * pad) / 2 : i * (width - 2 * pad) / (points.length - 1));
+  const y = (score) => height - pad - score * (height - 2 * pad);
+
+  for (const level of [0, 0.6, 0.85, 1]) {
+    chart.append(svg("line", { x1: pad, x2: width - pad, y1: y(level), y2: y(level), stroke: "#2a2f3a" }));
+    const label = svg("text", { x: 2, y: y(level) + 3 });
+    label.textContent = level.toFixed(2);
+    chart.append(label);
+  }
+
+  const path = points.map((p, i) => `${i === 0 ? "M" : "L"}${x(i)},${y(p.p_score)}`).join(" ");
+  chart.append(svg("path", { d: path, fill: "none", stroke: "#4fc3f7", "stroke-width": 2 }));
+
+  points.forEach((p, i) => {
+    const dot = svg("circle", { cx: x(i), cy: y(p.p_score), r: 3, fill: "#4fc3f7" });
+    const title = svg("title");
+    title.textContent = `${p.date}: ${p.p_score.toFixed(2)} (${p.attempts} attempts)`;
+    dot.append(title);
+    chart.append(dot);
+  });
+
+  const first = svg("text", { x: pad, y: height - 8 });
+  first.textContent = points[0].date;
+  const last = svg("text", { x: width - pad, y: height - 8, "text-anchor": "end" });
+  last.textContent = points[points.length - 1].date;
+  chart.append(first, last);
+
+  container.append(chart);
+}
+
+function renderHeatmap(counts) {
+  const container = document.getElementById("heatmap");
+  container.replaceChildren();
+
+  const cell = 12, gap = 3, weeks = 53;
+  const chart = svg("svg", { width: weeks * (cell + gap) + 30, height: 7 * (cell + gap) + 20 });
+  const colors = ["#1f232b", "#0e4429", "#006d32", "#26a641", "#39d353"];
+
+  const today = new Date();
+  const start = new Date(today);
+  start.setDate(start.getDate() - (weeks - 1) * 7 - today.getDay());
+
+  for (let day = new Date(start); day <= today; day.setDate(day.getDate() + 1)) {
+    const offset = Math.round((day - start) / 86400000);
+    const week = Math.floor(offset / 7);
+    const key = formatDate(day);
+    const count = counts[key] || 0;
+    const rect = svg("rect", {
+      x: 30 + week * (cell + gap),
+      y: day.getDay() * (cell + gap),
+      width: cell,
+      height: cell,
+      rx: 2,
+      fill: colors[Math.min(count, colors.length - 1)],
+    });
+    const title = svg("title");
+    title.textContent = `${key}: ${count} attempts`;
+    rect.append(title);
+    chart.append(rect);
+  }
+
+  ["Mon", "Wed", "Fri"].forEach((label, i) => {
+    const text = svg("text", { x: 0, y: (i * 2 + 1) * (cell + gap) + cell - 2 });
+    text.textContent = label;
+    chart.append(text);
+  });
+
+  container.append(chart);
+}
+
+function formatDate(date) {
+  const month = String(date.getMonth() + 1).padStart(2, "0");
+  const day = String(date.getDate()).padStart(2, "0");
+  return `${date.getFullYear()}-${month}-${day}`;
+}
+
+// API token, pasted once and kept in this browser's local storage
+
+const tokenDialog = document.getElementById("token-dialog");
+const tokenForm = document.getElementById("token-form");
+
+function askToken() {
+  if (!tokenDialog.open) {
+    tokenForm.reset();
+    tokenDialog.showModal();
+  }
+}
+
+tokenForm.addEventListener("submit", () => {
+  localStorage.setItem(TOKEN_KEY, tokenForm.elements.token.value.trim());
+  loaders[document.querySelector(".tab.active").dataset.view]();
+});
+
+const loaders = { today: loadToday, questions: loadQuestions, stats: loadStats };
+loadToday();
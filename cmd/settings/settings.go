@@ -0,0 +1,98 @@
+package settings
+
+import (
+	"dsacli/config"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// GetCommand returns the parent command used to view and change the user's settings
+func GetCommand(cfg config.Config) *cobra.Command {
+	Command := &cobra.Command{
+		Use:   "config",
+		Short: "View or change settings",
+		Long:  fmt.Sprintf("View or change settings. Settings are stored in %s.", cfg.SettingsPath),
+		Args:  cobra.NoArgs,
+		Run:   showCmd(cfg),
+	}
+
+	Command.AddCommand(&cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the value of a setting",
+		Args:  cobra.ExactArgs(1),
+		Run:   getCmd(cfg),
+	})
+	Command.AddCommand(&cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change a setting",
+		Args:  cobra.ExactArgs(2),
+		Run:   setCmd(cfg),
+	})
+	Command.AddCommand(&cobra.Command{
+		Use:   "unset <key>",
+		Short: "Restore a setting to its default",
+		Args:  cobra.ExactArgs(1),
+		Run:   unsetCmd(cfg),
+	})
+
+	return Command
+}
+
+func showCmd(cfg config.Config) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		for _, key := range config.SettingKeys() {
+			value, _ := cfg.Settings.Get(key)
+			if value == "" {
+				value = "(not set)"
+			}
+			color.Cyan("%s = %s", key, value)
+			fmt.Printf("    %s\n", config.DescribeSetting(key))
+		}
+	}
+}
+
+func getCmd(cfg config.Config) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		value, err := cfg.Settings.Get(args[0])
+		if err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+		fmt.Println(value)
+	}
+}
+
+func setCmd(cfg config.Config) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executeSet(cfg, args[0], args[1]); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+		color.Green("Set %s to %s", args[0], args[1])
+	}
+}
+
+func unsetCmd(cfg config.Config) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		settings := cfg.Settings
+		if err := settings.Reset(args[0]); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+		if err := config.SaveSettings(cfg.SettingsPath, settings); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+		color.Green("Reset %s to its default", args[0])
+	}
+}
+
+func executeSet(cfg config.Config, key, value string) error {
+	settings := cfg.Settings
+	if err := settings.Set(key, value); err != nil {
+		return err
+	}
+	return config.SaveSettings(cfg.SettingsPath, settings)
+}
@@ -0,0 +1,179 @@
+package show
+
+import (
+	"dsacli/practice"
+	"dsacli/types"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// maxPlans is the number of past plan entries listed
+const maxPlans = 5
+
+var statement bool
+
+func GetCommand(service *practice.Service) *cobra.Command {
+	Command := &cobra.Command{
+		Use:   "show [question_id]",
+		Short: "Show a question and why it was planned",
+		Long: `Show a question with its review schedule, notes and the plans it was picked for, including why the planner picked it.
+
+With --statement the problem statement stored with the question is printed instead, to practice offline.`,
+		Args: cobra.ExactArgs(1),
+		Run:  showCmd(service),
+	}
+
+	Command.Flags().BoolVarP(&statement, "statement", "s", false, "Print the stored problem statement, examples and constraints")
+
+	return Command
+}
+
+func showCmd(service *practice.Service) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executeShow(service, args); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+func executeShow(service *practice.Service, args []string) error {
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid question id %q", args[0])
+	}
+
+	if statement {
+		return executeStatement(service, uint(id))
+	}
+
+	details, err := service.Details(uint(id))
+	if errors.Is(err, practice.ErrQuestionNotFound) {
+		return fmt.Errorf("question with ID %d not found", id)
+	}
+	if err != nil {
+		return err
+	}
+
+	q := details.Question
+	color.Cyan("%d. %s (%s)", q.ID, q.Name, q.Difficulty)
+	fmt.Printf("URL: %s\n", q.URL)
+	if len(q.Tags) > 0 {
+		fmt.Printf("Tags: %s\n", strings.Join(q.Tags, ", "))
+	}
+	if q.Premium {
+		fmt.Printf("Platform: %s (premium)\n", q.Platform)
+	} else if q.Platform != "" {
+		fmt.Printf("Platform: %s\n", q.Platform)
+	}
+	if q.Section != "" {
+		fmt.Printf("Section: %s\n", q.Section)
+	}
+	if len(q.Companies) > 0 {
+		fmt.Printf("Companies: %s\n", strings.Join(q.Companies, ", "))
+	}
+	if len(q.Lists) > 0 {
+		fmt.Printf("Lists: %s\n", strings.Join(q.Lists, ", "))
+	}
+	if q.Archived {
+		color.Yellow("Archived")
+	}
+	if q.Suspended {
+		color.Yellow("Suspended, resume it with 'dsacli leeches resume %d'", q.ID)
+	}
+
+	if !q.Attempted {
+		fmt.Println("Never attempted")
+	} else {
+		fmt.Printf("Attempts: %d, last p-score %.2f, easiness factor %.2f\n", q.AttemptCount, q.LastPScore, q.EasinessFactor)
+		fmt.Printf("Review interval: %d day(s), due %s\n", q.ReviewInterval, details.Due)
+		if q.Mastered {
+			color.Green("Mastered")
+		}
+	}
+	if q.Notes != "" {
+		fmt.Printf("Notes: %s\n", q.Notes)
+	}
+
+	if details.HasStatement {
+		fmt.Printf("Statement stored, read it with 'dsacli show %d --statement'\n", q.ID)
+	}
+
+	printLinked("Prerequisites", details.Prerequisites)
+	printLinked("Dependents", details.Dependents)
+
+	if entry, ok := details.LastPick(); ok {
+		fmt.Println()
+		color.Cyan("Last picked on %s as %s", entry.Date, entry.Pick)
+		fmt.Printf("  Phase %s, among %d candidate(s)", entry.Pick.Phase, entry.Pick.Pool)
+		if entry.Pick.Seed != 0 {
+			fmt.Printf(", seed %d", entry.Pick.Seed)
+		}
+		fmt.Println()
+	}
+
+	if len(details.Plans) > 0 {
+		fmt.Println()
+		color.Cyan("Plans:")
+		for _, entry := range details.Plans[:min(maxPlans, len(details.Plans))] {
+			status := "open"
+			switch {
+			case entry.Completed:
+				status = "completed"
+			case entry.CarriedOver:
+				status = "carried over"
+			case entry.Dismissed:
+				status = "dismissed"
+			}
+			line := fmt.Sprintf("  %s  %s", entry.Date, status)
+			if entry.CarriedFrom != "" {
+				line += fmt.Sprintf(" (carried over from %s)", entry.CarriedFrom)
+			}
+			fmt.Println(line)
+		}
+	}
+	return nil
+}
+
+func executeStatement(service *practice.Service, id uint) error {
+	question, err := service.Question(id)
+	if errors.Is(err, practice.ErrQuestionNotFound) {
+		return fmt.Errorf("question with ID %d not found", id)
+	}
+	if err != nil {
+		return err
+	}
+	content, err := service.Statement(id)
+	if errors.Is(err, practice.ErrNoStatement) {
+		return fmt.Errorf("no statement stored for '%s', import one with 'dsacli seed <markdown_dir>'", question.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	color.Cyan("%d. %s (%s)", question.ID, question.Name, question.Difficulty)
+	fmt.Println()
+	fmt.Println(RenderStatement(content))
+	return nil
+}
+
+// printLinked lists the prerequisites or dependents of a question with whether they were attempted
+func printLinked(title string, questions []types.Question) {
+	if len(questions) == 0 {
+		return
+	}
+	fmt.Println()
+	color.Cyan("%s:", title)
+	for _, q := range questions {
+		status := "❌"
+		if q.Attempted {
+			status = "✅"
+		}
+		fmt.Printf("  %s %d. %s (%s)\n", status, q.ID, q.Name, q.Difficulty)
+	}
+}
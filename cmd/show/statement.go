@@ -0,0 +1,45 @@
+package show
+
+import (
+	"dsacli/types"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+var (
+	boldPattern = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	codePattern = regexp.MustCompile("`([^`]+)`")
+
+	headingColor = color.New(color.FgCyan, color.Bold)
+	boldColor    = color.New(color.Bold)
+	codeColor    = color.New(color.FgYellow)
+)
+
+// RenderStatement renders a stored problem statement for the terminal: headings are
+// highlighted, code blocks are indented and inline bold and code markers are replaced by colors
+func RenderStatement(content types.QuestionContent) string {
+	var lines []string
+	inCode := false
+	for _, line := range strings.Split(content.Markdown(), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			inCode = !inCode
+		case inCode:
+			lines = append(lines, "    "+codeColor.Sprint(line))
+		case strings.HasPrefix(trimmed, "#"):
+			lines = append(lines, headingColor.Sprint(strings.TrimSpace(strings.TrimLeft(trimmed, "#"))))
+		default:
+			line = boldPattern.ReplaceAllStringFunc(line, func(m string) string {
+				return boldColor.Sprint(boldPattern.FindStringSubmatch(m)[1])
+			})
+			line = codePattern.ReplaceAllStringFunc(line, func(m string) string {
+				return codeColor.Sprint(codePattern.FindStringSubmatch(m)[1])
+			})
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
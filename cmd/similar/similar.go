@@ -0,0 +1,70 @@
+package similar
+
+import (
+	"dsacli/practice"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var limit int
+
+func GetCommand(service *practice.Service) *cobra.Command {
+	Command := &cobra.Command{
+		Use:   "similar [question_id]",
+		Short: "Find questions practicing the same pattern",
+		Long: `Rank the other questions by how well they practice the same pattern: the tags they share, being
+in the same section of the problem list, and how much they still need practicing (never attempted
+or a low p-score first).`,
+		Args: cobra.ExactArgs(1),
+		Run:  similarCmd(service),
+	}
+	Command.Flags().IntVarP(&limit, "limit", "n", practice.DefaultSimilarLimit, "Maximum number of questions to show")
+	return Command
+}
+
+func similarCmd(service *practice.Service) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executeSimilar(service, args); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+func executeSimilar(service *practice.Service, args []string) error {
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid question id %q", args[0])
+	}
+	if limit < 1 {
+		return fmt.Errorf("limit must be at least 1")
+	}
+
+	question, err := service.Question(uint(id))
+	if errors.Is(err, practice.ErrQuestionNotFound) {
+		return fmt.Errorf("question with ID %d not found", id)
+	}
+	if err != nil {
+		return err
+	}
+	similar, err := service.Similar(question.ID, limit)
+	if err != nil {
+		return err
+	}
+
+	if len(similar) == 0 {
+		color.Yellow("No question shares a tag or section with '%s'.", question.Name)
+		return nil
+	}
+	color.Cyan("Questions similar to '%s':", question.Name)
+	for _, s := range similar {
+		q := s.Question
+		fmt.Printf("  %d. %s (%s) %.2f - %s\n", q.ID, q.Name, q.Difficulty, s.Score, strings.Join(s.Reasons, ", "))
+	}
+	return nil
+}
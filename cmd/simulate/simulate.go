@@ -0,0 +1,143 @@
+package simulate
+
+import (
+	"dsacli/config"
+	"dsacli/db"
+	"dsacli/simulation"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	days         int
+	profileModel string
+)
+
+func GetCommand(database db.Database, cfg config.Config) *cobra.Command {
+	targets := config.DefaultTimeTargets()
+	Command := &cobra.Command{
+		Use:   "simulate",
+		Short: "Simulate practicing with a synthetic learner",
+		Long: fmt.Sprintf(`Run the scheduler day by day against a synthetic learner, starting from scratch with a copy of your
+question bank in a throwaway database. Your progress is not touched.
+
+The learner model is a JSON file; any field left out keeps its default:
+  {
+    "skill": {"easy": 0.7, "medium": 0.4, "hard": 0.15},  chance of solving a question never seen before
+    "recall": 0.95,                                          chance of solving it right after a review
+    "stability_days": 3,                                     initial half-life of the memory of a question
+    "success_growth": 2.5,                                   half-life multiplier after a solve
+    "failure_growth": 1.3,                                   half-life multiplier after a failure
+    "solve_minutes": {"easy": %d, "medium": %d, "hard": %d}, typical solve time, the default time targets
+    "practice_chance": 1                                     chance of practicing on a given day
+  }
+
+Use the global --seed flag to make runs reproducible.`, targets.Easy, targets.Medium, targets.Hard),
+		Args: cobra.NoArgs,
+		Run:  simulateCmd(database, cfg),
+	}
+
+	Command.Flags().IntVar(&days, "days", 90, "Number of days to simulate")
+	Command.Flags().StringVar(&profileModel, "profile-model", "", "JSON file describing the learner (defaults to a typical learner)")
+
+	return Command
+}
+
+func simulateCmd(database db.Database, cfg config.Config) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := executeSimulate(database, cfg); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+func executeSimulate(database db.Database, cfg config.Config) error {
+	model := simulation.DefaultLearnerModel()
+	if profileModel != "" {
+		var err error
+		if model, err = simulation.LoadLearnerModel(profileModel); err != nil {
+			return err
+		}
+	}
+
+	questions, err := database.GetActiveQuestions()
+	if err != nil {
+		return fmt.Errorf("loading questions: %w", err)
+	}
+	if len(questions) == 0 {
+		color.Yellow("No questions to simulate with. Seed the question bank first.")
+		return nil
+	}
+
+	dependencies, err := database.GetDependencies()
+	if err != nil {
+		return fmt.Errorf("loading prerequisites: %w", err)
+	}
+
+	color.Cyan("Simulating %d days with %d questions...", days, len(questions))
+	report, err := simulation.Run(questions, dependencies, simulation.Options{
+		Days:     days,
+		Model:    model,
+		Settings: cfg.Settings,
+		Start:    cfg.Clock.Now(),
+	}, cfg.Rand)
+	if err != nil {
+		return err
+	}
+
+	printReport(report)
+	return nil
+}
+
+func printReport(report simulation.Report) {
+	avg, peak := report.ReviewsPerDay()
+	fmt.Println()
+	color.Cyan("📅 Workload")
+	fmt.Printf("   Attempts: %d (%d solved, %.0f%%)\n", report.Attempts, report.Solved, percentage(report.Solved, report.Attempts))
+	fmt.Printf("   Reviews per practice day: %.1f on average, %d at most\n", avg, peak)
+	fmt.Println("   Reviews per week:", weeklyReviews(report.Days))
+
+	fmt.Println()
+	color.Cyan("🔓 Progression gates")
+	for _, unlock := range report.TierUnlocks {
+		if unlock.Day < 0 {
+			color.Red("   %s: still locked after %d days", unlock.Difficulty, len(report.Days))
+			continue
+		}
+		color.Green("   %s: unlocked on day %d", unlock.Difficulty, unlock.Day)
+	}
+
+	fmt.Println()
+	color.Cyan("🎯 Final mastery")
+	for _, tier := range report.FinalTiers {
+		fmt.Printf("   %s: %d/%d mastered (%.1f%%)\n", tier.Difficulty, tier.Mastered, tier.Total, tier.MasteryPercentage)
+	}
+	fmt.Printf("   Overall: %d attempted, %d/%d mastered (%.1f%%)\n", report.Attempted, report.Mastered, report.Questions, percentage(report.Mastered, report.Questions))
+	if len(report.Days) > 0 {
+		fmt.Printf("   Due for review at the end: %d\n", report.Days[len(report.Days)-1].Due)
+	}
+}
+
+// weeklyReviews sums the reviews of every 7 simulated days
+func weeklyReviews(days []simulation.DayStats) string {
+	var weeks []string
+	for start := 0; start < len(days); start += 7 {
+		total := 0
+		for _, day := range days[start:min(start+7, len(days))] {
+			total += day.Reviews
+		}
+		weeks = append(weeks, fmt.Sprint(total))
+	}
+	return strings.Join(weeks, " ")
+}
+
+func percentage(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
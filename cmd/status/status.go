@@ -1,56 +1,73 @@
 package status
 
 import (
-	"dsacli/common"
-	"dsacli/db"
+	"dsacli/cmd/goal"
+	"dsacli/practice"
 	"dsacli/types"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
-func GetCommand(db db.Database) *cobra.Command {
+func GetCommand(service *practice.Service) *cobra.Command {
 	return &cobra.Command{
 		Use:   "status",
 		Short: "Show the status of learning progression",
 		Long:  "Shows the state of solved and unsolved problems",
-		Run:   printStatus(db),
+		Run:   printStatus(service),
 	}
 }
 
-func printStatus(db db.Database) func(cmd *cobra.Command, args []string) {
+func printStatus(service *practice.Service) func(cmd *cobra.Command, args []string) {
 	return func(cmd *cobra.Command, args []string) {
-		attempted, err := db.GetAllAttemptedQuestions()
+		status, err := service.Status()
 		if err != nil {
-			cmd.Println("Error fetching attempted questions:", err)
+			cmd.Println("Error fetching status:", err)
 			return
 		}
 
-		masteredQns := common.FilterSlice(attempted, func(q types.Question) bool {
-			return q.Mastered
-		})
-
-		if len(masteredQns) == 0 {
+		if len(status.MasteredQns) == 0 {
 			color.Yellow("No questions marked as mastered yet.")
 		} else {
-			color.Green("Mastered Questions (%d):", len(masteredQns))
-			for _, q := range masteredQns {
+			color.Green("Mastered Questions (%d):", len(status.MasteredQns))
+			for _, q := range status.MasteredQns {
 				cmd.Printf("    - %s\n", q.Name)
 			}
 		}
 
-		nonMasteredQns := common.FilterSlice(attempted, func(q types.Question) bool {
-			return !q.Mastered
-		})
-
-		if len(nonMasteredQns) == 0 {
+		if len(status.NonMasteredQns) == 0 {
 			color.Yellow("All attempted questions are mastered.")
 		} else {
-			color.Red("Non-Mastered Questions (%d):", len(nonMasteredQns))
-			for _, q := range nonMasteredQns {
+			color.Red("Non-Mastered Questions (%d):", len(status.NonMasteredQns))
+			for _, q := range status.NonMasteredQns {
 				cmd.Printf("    - %s\n", q.Name)
 			}
 		}
 
+		if len(status.Failures) > 0 {
+			color.Cyan("Why attempts went wrong:")
+			for _, f := range status.Failures {
+				cmd.Printf("    - %s: %d (%.0f%%)\n", types.FailureDescriptions[f.Category], f.Count, f.Percentage)
+			}
+		}
+
+		printCoverage(cmd, "Coverage by company:", status.Companies)
+		printCoverage(cmd, "Coverage by list:", status.Lists)
+
+		if status.Readiness != nil {
+			goal.PrintReadiness(*status.Readiness)
+		}
+	}
+}
+
+// printCoverage prints how much of each company's or list's questions were attempted and mastered
+func printCoverage(cmd *cobra.Command, title string, coverage []practice.Coverage) {
+	if len(coverage) == 0 {
+		return
+	}
+	color.Cyan(title)
+	for _, c := range coverage {
+		cmd.Printf("    - %s: %d/%d attempted (%.0f%%), %d mastered\n",
+			c.Name, c.Attempted, c.Total, float64(c.Attempted)/float64(c.Total)*100, c.Mastered)
 	}
 }
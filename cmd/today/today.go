@@ -2,211 +2,197 @@ package today
 
 import (
 	"dsacli/common"
-	"dsacli/db"
+	"dsacli/practice"
 	"dsacli/types"
 	"fmt"
-	"math/rand"
 	"os/exec"
 	"runtime"
-	"sort"
 	"strings"
 
 	"github.com/fatih/color"
-	_ "github.com/mattn/go-sqlite3"
 	"github.com/spf13/cobra"
 )
 
-const (
-	questionsPerDay = 2
-	easyPhase       = "easy"
-	mediumPhase     = "medium"
-	hardPhase       = "hard"
+var (
+	More    = false
+	Explain = false
+	Company = ""
 )
 
-var More = false
-
-func GetCommand(db db.Database) *cobra.Command {
+func GetCommand(service *practice.Service) *cobra.Command {
 	Command := &cobra.Command{
 		Use:   "today",
 		Short: "Suggests two DSA questions for today",
 		Long:  `Suggests two DSA questions for today based on difficulty progression and smart review.`,
-		Run:   todayCmd(db),
+		Run:   todayCmd(service),
 	}
 
 	Command.Flags().BoolVarP(&More, "more", "m", false, "Show more questions (after completing today's questions)")
+	Command.Flags().BoolVarP(&Explain, "explain", "e", false, "Explain why each question was picked")
+	Command.Flags().StringVar(&Company, "company", "", "Pick new questions asked by these companies first (e.g. amazon,google), overrides the focus-companies setting")
 
 	return Command
 }
 
-func todayCmd(db db.Database) func(cmd *cobra.Command, args []string) {
+func todayCmd(service *practice.Service) func(cmd *cobra.Command, args []string) {
 	return func(cmd *cobra.Command, args []string) {
-		executeToday(db)
+		if err := executeToday(service); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
 	}
 }
 
-func executeToday(db db.Database) {
-	// Check if today's questions already exist
-	questionsWithStatus, err := getTodayQuestionsWithStatusIfExist(db)
-	if err == nil && len(questionsWithStatus) > 0 {
-		// If all completed and more flag is set, generate new questions
-		if !allCompleted(questionsWithStatus) {
-			displayTodayQuestions(questionsWithStatus)
-			return
-		} else if !More {
-			// If all questions are completed and more flag is not set, notify user.
-			color.Green("You have already completed today's questions!")
-			color.Yellow("Use --more flag to see more questions.")
-			return
-		} else {
-			color.Cyan("You have completed today's questions. Generating new ones...")
-		}
+func executeToday(service *practice.Service) error {
+	if Company != "" {
+		service.FocusOn(common.SplitCSV(Company))
+	}
+	plan, err := service.PlanToday(More)
+	if err != nil {
+		return err
+	}
+
+	if plan.PausedUntil != "" {
+		color.Yellow("Practice is paused until %s. Run 'dsacli pause --resume' to start again early.", plan.PausedUntil)
+		return nil
 	}
 
-	questionIdsToIgnore := make([]uint, 0)
-	// If more flag is set, retrieve today's questions to avoid duplicates
-	if More {
-		for _, qws := range questionsWithStatus {
-			questionIdsToIgnore = append(questionIdsToIgnore, qws.Question.ID)
+	if !plan.Generated {
+		if Company != "" {
+			color.Yellow("Today's questions were already picked, --company applies to the next ones (e.g. with --more).")
+		}
+		if !plan.AllCompleted() {
+			if Explain {
+				if err := explainQuestions(service, plan.Questions); err != nil {
+					return err
+				}
+			}
+			displayTodayQuestions(plan.Questions)
+			return nil
 		}
+		// If all questions are completed and more flag is not set, notify user.
+		color.Green("You have already completed today's questions!")
+		color.Yellow("Use --more flag to see more questions.")
+		return nil
 	}
 
-	// Generate new questions for today
-	questions, err := generateTodayQuestions(db, questionIdsToIgnore)
-	if err != nil {
-		color.Red("Error generating today's questions: %v", err)
-		return
+	if plan.Extra {
+		color.Cyan("You have completed today's questions. Generating new ones...")
+	}
+	if plan.Carried > 0 {
+		color.Yellow("Carried over %d unfinished question(s) from missed days.", plan.Carried)
+	}
+	printPhase(plan.Phase)
+	if plan.InterviewIn > 0 {
+		color.Cyan("%d days until your interview", plan.InterviewIn)
+	}
+	if plan.BudgetMinutes > 0 {
+		color.Cyan("~%d of %d minutes planned", plan.PlannedMinutes, plan.BudgetMinutes)
 	}
 
-	if len(questions) == 0 {
+	if len(plan.Questions) == 0 {
 		fmt.Println("No questions found")
-		return
+		return nil
+	}
+
+	questions := make([]types.Question, len(plan.Questions))
+	for i, qws := range plan.Questions {
+		questions[i] = qws.Question
 	}
 
-	if err := db.InsertTodayQuestions(questions); err != nil {
-		color.Red("Unable to save today's questions: %v", err)
+	if Explain {
+		printTrace(plan.Trace)
+		if err := explainQuestions(service, plan.Questions); err != nil {
+			return err
+		}
 	}
 
 	color.Cyan("Here are your questions for today:")
 	displayQuestions(questions)
-
+	return nil
 }
 
-// getTodayQuestionsWithStatusIfExist retrieves today's questions with completion status if they already exist
-func getTodayQuestionsWithStatusIfExist(db db.Database) ([]types.TodayQuestionWithStatus, error) {
-	return db.GetTodayQuestionsWithStatus()
+// printPhase tells the user which phase today's questions were picked for
+func printPhase(phase string) {
+	switch phase {
+	case practice.EasyPhase:
+		color.Green("Focusing on: Easy Questions")
+	case practice.MediumPhase:
+		color.Yellow("Focusing on: Medium Questions (with Smart Review)")
+	case practice.HardPhase:
+		color.Red("Focusing on: Hard Questions (with Smart Review)")
+	case practice.MasteryPhase:
+		color.Magenta("Mastery Mode: Reviewing all questions!")
+	case practice.FinalWeekPhase:
+		color.Magenta("Final week before your interview: reviewing weak questions only")
+	}
 }
 
-// generateTodayQuestions generates new questions based on difficulty progression
-func generateTodayQuestions(db db.Database, questionsToIgnore []uint) ([]types.Question, error) {
-	// Load questions by difficulty
-	easyQuestions, err := db.GetQuestionsByDifficulty(easyPhase)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load easy questions: %w", err)
+// printTrace prints the decisions the planner made while generating the plan
+func printTrace(trace *practice.Trace) {
+	if trace == nil {
+		return
 	}
-	if !allAttempted(easyQuestions) {
-		questions := generateEasyPhaseQuestions(easyQuestions)
-		if len(questions) > 0 {
-			return questions, nil
+	color.Cyan("Planner decisions:")
+	fmt.Printf("  Phase: %s\n", trace.Phase)
+	fmt.Printf("  Seed: %d\n", trace.Seed)
+	for _, kind := range []string{types.PickNew, types.PickReview} {
+		if n, ok := trace.Pools[kind]; ok {
+			fmt.Printf("  %s candidates: %d\n", strings.ToUpper(kind[:1])+kind[1:], n)
 		}
 	}
-
-	mediumQuestions, err := db.GetQuestionsByDifficulty(mediumPhase)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load medium questions: %w", err)
-	}
-	if !allAttempted(mediumQuestions) {
-		return generateMediumPhaseQuestions(mediumQuestions, easyQuestions), nil
+	if len(trace.Companies) > 0 {
+		fmt.Printf("  Company focus: %s\n", strings.Join(trace.Companies, ", "))
 	}
-
-	hardQuestions, err := db.GetQuestionsByDifficulty(hardPhase)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load hard questions: %w", err)
+	if trace.Blocked > 0 {
+		fmt.Printf("  Held back until their prerequisites are attempted: %d new question(s)\n", trace.Blocked)
 	}
-	allQuestions, err := db.GetAllQuestions()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load all questions: %w", err)
+	if len(trace.Candidates) > 0 {
+		fmt.Println("  Scored candidates:")
+		for _, c := range trace.Candidates {
+			marker := " "
+			if c.Picked {
+				marker = "*"
+			}
+			fmt.Printf("   %s %6.2f  %s\n", marker, c.Score, c.Name)
+		}
 	}
-	if !allAttempted(hardQuestions) {
-		return generateHardPhaseQuestions(hardQuestions, allQuestions), nil
+	for _, tieBreak := range trace.TieBreaks {
+		fmt.Printf("  Tie-break: %s\n", tieBreak)
 	}
-
-	return generateMasteryPhaseQuestions(allQuestions), nil
+	fmt.Println()
 }
 
-// generateEasyPhaseQuestions generates questions for the easy phase
-func generateEasyPhaseQuestions(easyQuestions []types.Question) []types.Question {
-	color.Green("Focusing on: Easy Questions")
-
-	var questions []types.Question
-
-	// Get first question
-	if q1, hasQuestion := getFocusQuestion(easyQuestions); hasQuestion {
-		questions = append(questions, q1)
-
-		// Get second question from remaining easy questions
-		remaining := filterOutQuestion(easyQuestions, q1.ID)
-		if q2, hasQuestion := getFocusQuestion(remaining); hasQuestion {
-			questions = append(questions, q2)
+// explainQuestions prints why each question of the plan was picked. Plans made before picks
+// were recorded fall back to the current review priority.
+func explainQuestions(service *practice.Service, planned []types.TodayQuestionWithStatus) error {
+	var unrecorded []types.Question
+	for _, qws := range planned {
+		if qws.Pick == nil {
+			unrecorded = append(unrecorded, qws.Question)
 		}
 	}
-
-	return questions
-}
-
-// generateMediumPhaseQuestions generates questions for the medium phase with smart review
-func generateMediumPhaseQuestions(mediumQuestions, easyQuestions []types.Question) []types.Question {
-	color.Yellow("Focusing on: Medium Questions (with Smart Review)")
-
-	var questions []types.Question
-
-	// Get focus question from medium difficulty
-	if qFocus, hasQuestion := getFocusQuestion(mediumQuestions); hasQuestion {
-		questions = append(questions, qFocus)
-
-		// Get review question from attempted easy/medium questions
-		attemptedPool := buildAttemptedPool(append(easyQuestions, mediumQuestions...), qFocus.ID)
-		if qReview, hasQuestion := getHighestSRQuestion(attemptedPool); hasQuestion {
-			questions = append(questions, qReview)
-		}
+	priorities, err := service.Explain(unrecorded)
+	if err != nil {
+		return err
 	}
 
-	return questions
-}
-
-// generateHardPhaseQuestions generates questions for the hard phase with smart review
-func generateHardPhaseQuestions(hardQuestions, allQuestions []types.Question) []types.Question {
-	color.Red("Focusing on: Hard Questions (with Smart Review)")
-
-	var questions []types.Question
-
-	// Get focus question from hard difficulty
-	if qFocus, hasQuestion := getFocusQuestion(hardQuestions); hasQuestion {
-		questions = append(questions, qFocus)
-
-		// Get review question from all attempted questions
-		attemptedPool := buildAttemptedPool(allQuestions, qFocus.ID)
-		if qReview, hasQuestion := getHighestSRQuestion(attemptedPool); hasQuestion {
-			questions = append(questions, qReview)
+	color.Cyan("Why these questions:")
+	for i, qws := range planned {
+		var why string
+		if qws.Pick != nil {
+			why = fmt.Sprintf("picked as %s", qws.Pick)
+		} else {
+			p := priorities[qws.Question.ID]
+			why = fmt.Sprintf("priority %.2f: %s", p.Score, strings.Join(p.Reasons, ", "))
 		}
+		if qws.CarriedFrom != "" {
+			why += fmt.Sprintf(" (carried over from %s)", qws.CarriedFrom)
+		}
+		fmt.Printf("  %d. %s - %s\n", i+1, qws.Question.Name, why)
 	}
-
-	return questions
-}
-
-// generateMasteryPhaseQuestions generates questions for the mastery phase
-func generateMasteryPhaseQuestions(allQuestions []types.Question) []types.Question {
-	color.Magenta("Mastery Mode: Reviewing all questions!")
-
-	// Sort by SR score (highest first)
-	sort.Slice(allQuestions, func(i, j int) bool {
-		return allQuestions[i].LastPScore > allQuestions[j].LastPScore
-	})
-
-	if len(allQuestions) >= questionsPerDay {
-		return allQuestions[:questionsPerDay]
-	}
-
-	return allQuestions
+	fmt.Println()
+	return nil
 }
 
 func displayQuestions(questions []types.Question) {
@@ -224,7 +210,7 @@ func displayQuestions(questions []types.Question) {
 	question := questions[idx]
 	color.Cyan("Opening question: %s (%s)", question.Name, question.URL)
 	// Open question.URL in the default browser
-	if err := openBrowser(question.URL); err != nil {
+	if err := OpenBrowser(question.URL); err != nil {
 		color.Red("Error opening browser: %v", err)
 		return
 	}
@@ -266,105 +252,15 @@ func displayTodayQuestions(questionsWithStatus []types.TodayQuestionWithStatus)
 		question := displayedQns[idx]
 		color.Cyan("Opening question: %s (%s)", question.Name, question.URL)
 		// Open question.URL in the default browser
-		if err := openBrowser(question.URL); err != nil {
+		if err := OpenBrowser(question.URL); err != nil {
 			color.Red("Error opening browser: %v", err)
 			return
 		}
 	}
 }
 
-func filterOutQuestion(questions []types.Question, excludeID uint) []types.Question {
-	var filtered []types.Question
-	for _, q := range questions {
-		if q.ID != excludeID {
-			filtered = append(filtered, q)
-		}
-	}
-	return filtered
-}
-
-func buildAttemptedPool(questions []types.Question, excludeID uint) []types.Question {
-	var attemptedPool []types.Question
-	for _, q := range questions {
-		if q.Attempted && q.ID != excludeID {
-			attemptedPool = append(attemptedPool, q)
-		}
-	}
-	return attemptedPool
-}
-
-func getHighestSRQuestion(questions []types.Question) (types.Question, bool) {
-	if len(questions) == 0 {
-		return types.Question{}, false
-	}
-
-	maxSR := questions[0]
-	for _, q := range questions {
-		if q.LastPScore > maxSR.LastPScore {
-			maxSR = q
-		}
-	}
-
-	return maxSR, true
-}
-
-func allAttempted(questions []types.Question) bool {
-	if len(questions) == 0 {
-		return true
-	}
-
-	for _, q := range questions {
-		if !q.Attempted {
-			return false
-		}
-	}
-
-	return true
-}
-
-// getFocusQuestion returns the best question to focus on from the given pool
-// It prioritizes unattempted questions first, then questions with highest SR score
-func getFocusQuestion(pool []types.Question) (types.Question, bool) {
-	if len(pool) == 0 {
-		return types.Question{}, false
-	}
-
-	// First, try to get an unattempted question
-	unattempted := filterUnattemptedQuestions(pool)
-	if len(unattempted) > 0 {
-		return unattempted[rand.Intn(len(unattempted))], true
-	}
-
-	// If all are attempted, get the one with highest SR score
-	return getHighestSRQuestion(pool)
-}
-
-// filterUnattemptedQuestions returns only the questions that haven't been attempted
-func filterUnattemptedQuestions(questions []types.Question) []types.Question {
-	var unattempted []types.Question
-	for _, q := range questions {
-		if !q.Attempted {
-			unattempted = append(unattempted, q)
-		}
-	}
-	return unattempted
-}
-
-func allCompleted(questionsWithStatus []types.TodayQuestionWithStatus) bool {
-	if len(questionsWithStatus) == 0 {
-		return false
-	}
-
-	for _, qws := range questionsWithStatus {
-		if !qws.Completed {
-			return false
-		}
-	}
-
-	return true
-}
-
-func openBrowser(url string) error {
+// OpenBrowser opens the url in the default browser
+func OpenBrowser(url string) error {
 	var err error
 	// Cross-platform browser opening
 	switch runtime.GOOS {
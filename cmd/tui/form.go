@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"dsacli/practice"
+	"dsacli/types"
+	"fmt"
+	"strings"
+)
+
+// slider is a bounded integer input adjusted with the arrow keys
+type slider struct {
+	label string
+	value int
+	min   int
+	max   int
+	step  int
+	// format renders the current value, defaults to the plain number
+	format func(int) string
+}
+
+// increment moves to the next multiple of step
+func (s *slider) increment() {
+	next := (s.value/s.step + 1) * s.step
+	if s.value < 0 {
+		next = s.step
+	}
+	s.value = min(next, s.max)
+}
+
+// decrement moves to the previous multiple of step. A negative minimum is a
+// special value (e.g. "couldn't solve") reached by stepping below zero.
+func (s *slider) decrement() {
+	previous := ((s.value+s.step-1)/s.step - 1) * s.step
+	if previous <= 0 && s.min < 0 {
+		previous = s.min
+	}
+	s.value = max(previous, s.min)
+}
+
+func (s slider) render(width int) string {
+	span := s.max - s.min
+	filled := 0
+	if span > 0 {
+		filled = (s.value - s.min) * width / span
+	}
+	bar := strings.Repeat("■", filled) + strings.Repeat("·", width-filled)
+
+	value := fmt.Sprintf("%d", s.value)
+	if s.format != nil {
+		value = s.format(s.value)
+	}
+	return fmt.Sprintf("[%s] %s", bar, value)
+}
+
+const (
+	hintsSlider = iota
+	timeSlider
+	optimalitySlider
+	bugsSlider
+	confidenceSlider
+	solutionSlider
+	failureSlider
+)
+
+// feedbackForm collects the completion feedback with one slider per field
+type feedbackForm struct {
+	sliders []slider
+	focused int
+}
+
+func newFeedbackForm(minutes int) feedbackForm {
+	return feedbackForm{
+		sliders: []slider{
+			hintsSlider: {label: "Hints used", value: 0, min: 0, max: practice.MaxHints, step: 1},
+			timeSlider: {label: "Time taken", value: min(minutes, practice.MaxMinutes), min: practice.UnsolvedTimeValue, max: practice.MaxMinutes, step: 5, format: func(v int) string {
+				if v == practice.UnsolvedTimeValue {
+					return "couldn't solve"
+				}
+				return fmt.Sprintf("%d min", v)
+			}},
+			optimalitySlider: {label: "Optimality", value: 3, min: practice.MinRating, max: practice.MaxRating, step: 1, format: func(v int) string {
+				return fmt.Sprintf("%d (1=not optimal, 5=very optimal)", v)
+			}},
+			bugsSlider: {label: "Bugs", value: 3, min: practice.MinRating, max: practice.MaxRating, step: 1, format: func(v int) string {
+				return fmt.Sprintf("%d (1=many bugs, 5=no bugs)", v)
+			}},
+			confidenceSlider: {label: "Confidence", value: 0, min: 0, max: practice.MaxRating, step: 1, format: func(v int) string {
+				if v == 0 {
+					return "not rated"
+				}
+				return fmt.Sprintf("%d (1=guessing, 5=certain)", v)
+			}},
+			solutionSlider: {label: "Saw solution", value: 0, min: 0, max: 1, step: 1, format: func(v int) string {
+				if v == 1 {
+					return "yes"
+				}
+				return "no"
+			}},
+			failureSlider: {label: "Went wrong", value: 0, min: 0, max: len(types.FailureCategories), step: 1, format: func(v int) string {
+				if v == 0 {
+					return "nothing"
+				}
+				return types.FailureDescriptions[types.FailureCategories[v-1]]
+			}},
+		},
+	}
+}
+
+func (f *feedbackForm) next() {
+	f.focused = (f.focused + 1) % len(f.sliders)
+}
+
+func (f *feedbackForm) previous() {
+	f.focused = (f.focused - 1 + len(f.sliders)) % len(f.sliders)
+}
+
+func (f feedbackForm) feedback() practice.Feedback {
+	return practice.Feedback{
+		HintsNeeded:     f.sliders[hintsSlider].value,
+		TimeTaken:       f.sliders[timeSlider].value,
+		OptimalSolution: f.sliders[optimalitySlider].value,
+		AnyBugs:         f.sliders[bugsSlider].value,
+		Confidence:      f.sliders[confidenceSlider].value,
+		SawSolution:     f.sliders[solutionSlider].value == 1,
+		FailureCategory: f.failureCategory(),
+	}
+}
+
+// failureCategory returns the failure category picked with the slider, "" for nothing
+func (f feedbackForm) failureCategory() string {
+	if v := f.sliders[failureSlider].value; v > 0 {
+		return types.FailureCategories[v-1]
+	}
+	return ""
+}
@@ -0,0 +1,353 @@
+package tui
+
+import (
+	"dsacli/cmd/show"
+	"dsacli/cmd/today"
+	"dsacli/practice"
+	"dsacli/types"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type pane int
+
+const (
+	planPane pane = iota
+	duePane
+)
+
+type mode int
+
+const (
+	browseMode mode = iota
+	feedbackMode
+	notesMode
+	statementMode
+)
+
+type tickMsg time.Time
+
+// stopwatch tracks the time spent on a single question
+type stopwatch struct {
+	questionID uint
+	startedAt  time.Time
+	running    bool
+}
+
+func (s stopwatch) elapsed() time.Duration {
+	if !s.running {
+		return 0
+	}
+	return time.Since(s.startedAt)
+}
+
+type model struct {
+	service *practice.Service
+
+	data       dashboardData
+	focus      pane
+	planCursor int
+	dueCursor  int
+
+	mode      mode
+	stopwatch stopwatch
+	form      feedbackForm
+	notes     textarea.Model
+	statement viewport.Model
+	// editing is the question being completed, having its notes edited or its statement read
+	editing types.Question
+
+	status string
+	width  int
+	height int
+}
+
+func newModel(service *practice.Service) model {
+	notes := textarea.New()
+	notes.Placeholder = "Approach, edge cases, complexity..."
+	notes.ShowLineNumbers = false
+
+	return model{
+		service:   service,
+		notes:     notes,
+		statement: viewport.New(0, 0),
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return loadData(m.service)
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.notes.SetWidth(max(msg.Width-6, 20))
+		m.notes.SetHeight(max(msg.Height/3, 5))
+		// The statement pane has a border and padding of 4 columns and a title of 2 lines besides the header and footer
+		m.statement.Width = max(msg.Width-8, 20)
+		m.statement.Height = max(msg.Height-8, 5)
+		return m, nil
+
+	case dataLoadedMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Error: %v", msg.err)
+			return m, nil
+		}
+		m.data = msg.data
+		m.planCursor = clamp(m.planCursor, len(m.data.plan))
+		m.dueCursor = clamp(m.dueCursor, len(m.data.due))
+		return m, nil
+
+	case tickMsg:
+		if m.stopwatch.running {
+			return m, tick()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+
+		switch m.mode {
+		case feedbackMode:
+			return m.updateFeedback(msg)
+		case notesMode:
+			return m.updateNotes(msg)
+		case statementMode:
+			return m.updateStatement(msg)
+		default:
+			return m.updateBrowse(msg)
+		}
+	}
+
+	return m, nil
+}
+
+func (m model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+
+	case "tab":
+		if m.focus == planPane {
+			m.focus = duePane
+		} else {
+			m.focus = planPane
+		}
+
+	case "up", "k":
+		if m.focus == planPane {
+			m.planCursor = clamp(m.planCursor-1, len(m.data.plan))
+		} else {
+			m.dueCursor = clamp(m.dueCursor-1, len(m.data.due))
+		}
+
+	case "down", "j":
+		if m.focus == planPane {
+			m.planCursor = clamp(m.planCursor+1, len(m.data.plan))
+		} else {
+			m.dueCursor = clamp(m.dueCursor+1, len(m.data.due))
+		}
+
+	case "r":
+		m.status = "Refreshed"
+		return m, loadData(m.service)
+
+	case "o":
+		question, ok := m.selected()
+		if !ok {
+			return m, nil
+		}
+		if err := today.OpenBrowser(question.URL); err != nil {
+			m.status = fmt.Sprintf("Error opening browser: %v", err)
+		} else {
+			m.status = fmt.Sprintf("Opened %s", question.Name)
+		}
+
+	case "t":
+		question, ok := m.selected()
+		if !ok {
+			return m, nil
+		}
+		if m.stopwatch.running && m.stopwatch.questionID == question.ID {
+			m.status = fmt.Sprintf("Timer stopped at %s", formatDuration(m.stopwatch.elapsed()))
+			m.stopwatch = stopwatch{}
+			return m, nil
+		}
+		m.stopwatch = stopwatch{questionID: question.ID, startedAt: time.Now(), running: true}
+		m.status = fmt.Sprintf("Timer started for %s", question.Name)
+		return m, tick()
+
+	case "c":
+		question, ok := m.selected()
+		if !ok {
+			return m, nil
+		}
+		if m.focus == planPane && m.data.plan[m.planCursor].Completed {
+			m.status = fmt.Sprintf("%s is already completed today", question.Name)
+			return m, nil
+		}
+
+		minutes := 30
+		if m.stopwatch.running && m.stopwatch.questionID == question.ID {
+			minutes = max(int(m.stopwatch.elapsed().Round(time.Minute).Minutes()), 1)
+		}
+		m.editing = question
+		m.form = newFeedbackForm(minutes)
+		m.mode = feedbackMode
+
+	case "n":
+		question, ok := m.selected()
+		if !ok {
+			return m, nil
+		}
+		m.editing = question
+		m.notes.SetValue(question.Notes)
+		m.mode = notesMode
+		return m, m.notes.Focus()
+
+	case "s":
+		question, ok := m.selected()
+		if !ok {
+			return m, nil
+		}
+		content, err := m.service.Statement(question.ID)
+		if errors.Is(err, practice.ErrNoStatement) {
+			m.status = fmt.Sprintf("No statement stored for %s", question.Name)
+			return m, nil
+		}
+		if err != nil {
+			m.status = fmt.Sprintf("Error: %v", err)
+			return m, nil
+		}
+		m.editing = question
+		m.statement.SetContent(lipgloss.NewStyle().Width(m.statement.Width).Render(show.RenderStatement(content)))
+		m.statement.GotoTop()
+		m.mode = statementMode
+	}
+
+	return m, nil
+}
+
+func (m model) updateFeedback(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = browseMode
+		m.status = "Completion cancelled"
+
+	case "up", "k", "shift+tab":
+		m.form.previous()
+
+	case "down", "j", "tab":
+		m.form.next()
+
+	case "left", "h":
+		m.form.sliders[m.form.focused].decrement()
+
+	case "right", "l":
+		m.form.sliders[m.form.focused].increment()
+
+	case "enter":
+		result, err := m.service.RecordAttempt(m.editing.ID, m.form.feedback())
+		if err != nil {
+			m.status = fmt.Sprintf("Error: %v", err)
+			return m, nil
+		}
+		question := result.Question
+
+		if m.stopwatch.questionID == question.ID {
+			m.stopwatch = stopwatch{}
+		}
+		m.mode = browseMode
+		m.status = fmt.Sprintf("Completed %s: p-score %.2f, next review in %d days", question.Name, question.LastPScore, question.ReviewInterval)
+		if question.Mastered {
+			m.status += " (mastered 🎉)"
+		}
+		return m, loadData(m.service)
+	}
+
+	return m, nil
+}
+
+func (m model) updateNotes(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.notes.Blur()
+		m.mode = browseMode
+		m.status = "Notes unchanged"
+		return m, nil
+
+	case "ctrl+s":
+		question, err := m.service.SaveNotes(m.editing.ID, m.notes.Value())
+		if err != nil {
+			m.status = fmt.Sprintf("Error saving notes: %v", err)
+			return m, nil
+		}
+
+		m.notes.Blur()
+		m.mode = browseMode
+		m.status = fmt.Sprintf("Saved notes for %s", question.Name)
+		return m, loadData(m.service)
+	}
+
+	var cmd tea.Cmd
+	m.notes, cmd = m.notes.Update(msg)
+	return m, cmd
+}
+
+func (m model) updateStatement(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.mode = browseMode
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.statement, cmd = m.statement.Update(msg)
+	return m, cmd
+}
+
+// selected returns the question under the cursor of the focused pane
+func (m model) selected() (types.Question, bool) {
+	if m.focus == planPane {
+		if len(m.data.plan) == 0 {
+			return types.Question{}, false
+		}
+		return m.data.plan[m.planCursor].Question, true
+	}
+
+	if len(m.data.due) == 0 {
+		return types.Question{}, false
+	}
+	return m.data.due[m.dueCursor], true
+}
+
+// clamp keeps a cursor within [0, length)
+func clamp(cursor, length int) int {
+	if length == 0 || cursor < 0 {
+		return 0
+	}
+	if cursor >= length {
+		return length - 1
+	}
+	return cursor
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%02d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"dsacli/practice"
+	"dsacli/types"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const recentAttemptsLimit = 8
+
+func GetCommand(service *practice.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "tui",
+		Short: "Open the interactive dashboard",
+		Long:  `Open a full-screen dashboard showing today's plan, the due queue, progression gates, streak and recent attempts.`,
+		Run:   tuiCmd(service),
+	}
+}
+
+func tuiCmd(service *practice.Service) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		program := tea.NewProgram(newModel(service), tea.WithAltScreen())
+		if _, err := program.Run(); err != nil {
+			color.Red("Error: %v", err)
+			return
+		}
+	}
+}
+
+// dashboardData holds everything shown in the dashboard panes
+type dashboardData struct {
+	plan   []types.TodayQuestionWithStatus
+	due    []types.Question
+	tiers  []practice.TierProgress
+	streak int
+	recent []types.AttemptWithQuestion
+}
+
+type dataLoadedMsg struct {
+	data dashboardData
+	err  error
+}
+
+// loadData reads the dashboard data, generating today's plan if it doesn't exist yet
+func loadData(service *practice.Service) tea.Cmd {
+	return func() tea.Msg {
+		data, err := fetchData(service)
+		return dataLoadedMsg{data: data, err: err}
+	}
+}
+
+func fetchData(service *practice.Service) (dashboardData, error) {
+	var data dashboardData
+
+	plan, err := service.PlanToday(false)
+	if err != nil {
+		return data, err
+	}
+	data.plan = plan.Questions
+
+	data.due, err = service.Due()
+	if err != nil {
+		return data, fmt.Errorf("loading due questions: %w", err)
+	}
+
+	data.tiers, err = service.Progress()
+	if err != nil {
+		return data, err
+	}
+
+	data.streak, err = service.Streak()
+	if err != nil {
+		return data, err
+	}
+
+	data.recent, err = service.RecentAttempts(recentAttemptsLimit)
+	if err != nil {
+		return data, fmt.Errorf("loading recent attempts: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,209 @@
+package tui
+
+import (
+	"dsacli/types"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	titleStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("14"))
+	paneStyle    = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("8")).Padding(0, 1)
+	focusedStyle = paneStyle.BorderForeground(lipgloss.Color("14"))
+	cursorStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("11"))
+	mutedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	easyStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	mediumStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	hardStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+)
+
+func (m model) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	header := m.viewHeader()
+	footer := m.viewFooter()
+
+	var body string
+	switch m.mode {
+	case feedbackMode:
+		body = m.viewFeedback()
+	case notesMode:
+		body = m.viewNotes()
+	case statementMode:
+		body = m.viewStatement()
+	default:
+		body = m.viewDashboard(m.height - lipgloss.Height(header) - lipgloss.Height(footer))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, body, footer)
+}
+
+func (m model) viewHeader() string {
+	header := titleStyle.Render("dsacli dashboard")
+	header += fmt.Sprintf("   🔥 %d day streak", m.data.streak)
+	if m.stopwatch.running {
+		header += fmt.Sprintf("   ⏱  %s", formatDuration(m.stopwatch.elapsed()))
+	}
+	return header
+}
+
+func (m model) viewFooter() string {
+	var help string
+	switch m.mode {
+	case feedbackMode:
+		help = "↑/↓ select • ←/→ adjust • enter save • esc cancel"
+	case notesMode:
+		help = "ctrl+s save • esc cancel"
+	case statementMode:
+		help = "↑/↓ scroll • esc close"
+	default:
+		help = "tab switch pane • ↑/↓ move • o open • s statement • t timer • c complete • n notes • r refresh • q quit"
+	}
+
+	footer := mutedStyle.Render(help)
+	if m.status != "" {
+		footer = m.status + "\n" + footer
+	}
+	return footer
+}
+
+func (m model) viewDashboard(height int) string {
+	// Each pane has a border of 2 columns and padding of 2 columns
+	paneWidth := max(m.width/2-4, 20)
+	// Two rows of panes, each with a title line and 2 border lines
+	rows := max(height/2-3, 3)
+
+	plan := m.renderPane("Today's Plan", m.planLines(), m.planCursor, m.focus == planPane, paneWidth, rows)
+	due := m.renderPane(fmt.Sprintf("Due Queue (%d)", len(m.data.due)), m.dueLines(), m.dueCursor, m.focus == duePane, paneWidth, rows)
+	gates := m.renderPane("Progression Gates", m.gateLines(), -1, false, paneWidth, rows)
+	recent := m.renderPane("Recent Attempts", m.recentLines(), -1, false, paneWidth, rows)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.JoinHorizontal(lipgloss.Top, plan, due),
+		lipgloss.JoinHorizontal(lipgloss.Top, gates, recent),
+	)
+}
+
+// renderPane renders a bordered pane, scrolling the lines so the cursor stays visible.
+// A negative cursor renders the pane without a selection.
+func (m model) renderPane(title string, lines []string, cursor int, focused bool, width, rows int) string {
+	start := 0
+	if cursor >= rows {
+		start = cursor - rows + 1
+	}
+	end := min(start+rows, len(lines))
+
+	content := []string{titleStyle.Render(title)}
+	for idx := start; idx < end; idx++ {
+		line := truncate(lines[idx], width-2)
+		if idx == cursor && focused {
+			line = cursorStyle.Render("▸ " + line)
+		} else {
+			line = "  " + line
+		}
+		content = append(content, line)
+	}
+	if len(lines) == 0 {
+		content = append(content, mutedStyle.Render("  Nothing here"))
+	}
+
+	style := paneStyle
+	if focused {
+		style = focusedStyle
+	}
+	return style.Width(width).Height(rows + 1).Render(strings.Join(content, "\n"))
+}
+
+func (m model) planLines() []string {
+	var lines []string
+	for _, qws := range m.data.plan {
+		status := "[ ]"
+		if qws.Completed {
+			status = "[✓]"
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s", status, qws.Question.Name, difficultyLabel(qws.Question.Difficulty)))
+	}
+	return lines
+}
+
+func (m model) dueLines() []string {
+	var lines []string
+	now := m.service.Now()
+	for _, q := range m.data.due {
+		overdue := 0
+		if dueAt, ok := q.DueDate(); ok {
+			overdue = int(now.Sub(dueAt).Hours() / 24)
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s", q.Name, difficultyLabel(q.Difficulty), mutedStyle.Render(fmt.Sprintf("%dd overdue", overdue))))
+	}
+	return lines
+}
+
+func (m model) gateLines() []string {
+	var lines []string
+	for _, tier := range m.data.tiers {
+		icon := "🔒"
+		if tier.Unlocked {
+			icon = "🔓"
+		}
+		lines = append(lines, fmt.Sprintf("%s %-6s %d/%d mastered (%.1f%%)", icon, tier.Difficulty, tier.Mastered, tier.Total, tier.MasteryPercentage))
+	}
+	return lines
+}
+
+func (m model) recentLines() []string {
+	var lines []string
+	for _, a := range m.data.recent {
+		timeTaken := "unsolved"
+		if a.Attempt.TimeTaken >= 0 {
+			timeTaken = fmt.Sprintf("%dm", a.Attempt.TimeTaken)
+		}
+		lines = append(lines, fmt.Sprintf("%s %s p=%.2f %s", mutedStyle.Render(a.Attempt.Date), a.Question.Name, a.Attempt.PScore, timeTaken))
+	}
+	return lines
+}
+
+func (m model) viewFeedback() string {
+	lines := []string{titleStyle.Render(fmt.Sprintf("Complete: %s", m.editing.Name)), ""}
+	for idx, s := range m.form.sliders {
+		line := fmt.Sprintf("%-12s %s", s.label, s.render(20))
+		if idx == m.form.focused {
+			line = cursorStyle.Render("▸ " + line)
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	return focusedStyle.Width(max(m.width-4, 40)).Render(strings.Join(lines, "\n"))
+}
+
+func (m model) viewNotes() string {
+	content := titleStyle.Render(fmt.Sprintf("Notes: %s", m.editing.Name)) + "\n\n" + m.notes.View()
+	return focusedStyle.Width(max(m.width-4, 40)).Render(content)
+}
+
+func (m model) viewStatement() string {
+	content := titleStyle.Render(m.editing.Name) + "\n\n" + m.statement.View()
+	return focusedStyle.Width(max(m.width-4, 40)).Render(content)
+}
+
+func difficultyLabel(difficulty string) string {
+	switch difficulty {
+	case types.DifficultyEasy:
+		return easyStyle.Render("(easy)")
+	case types.DifficultyMedium:
+		return mediumStyle.Render("(medium)")
+	case types.DifficultyHard:
+		return hardStyle.Render("(hard)")
+	}
+	return "(" + difficulty + ")"
+}
+
+// truncate shortens a line so it fits within width columns, keeping styled segments intact
+func truncate(line string, width int) string {
+	return lipgloss.NewStyle().MaxWidth(width).Render(line)
+}
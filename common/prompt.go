@@ -8,27 +8,19 @@ import (
 	"strings"
 )
 
-func OneToFiveRatingValidator(input string) error {
-	value, err := strconv.Atoi(strings.TrimSpace(input))
-	if err != nil || value < 1 || value > 5 {
-		return fmt.Errorf("please enter a number between 1 and 5")
-	}
-	return nil
-}
+type IntValidator func(string) error
 
-func NumberValidator(input string) error {
-	num, err := strconv.Atoi(strings.TrimSpace(input))
-	if err != nil {
-		return fmt.Errorf("please enter a valid number >= -1")
-	}
-	if num < -1 {
-		return fmt.Errorf("please enter a number >= -1")
+// IntCheck turns a check of a parsed number into a prompt validator
+func IntCheck(check func(int) error) IntValidator {
+	return func(input string) error {
+		value, err := strconv.Atoi(strings.TrimSpace(input))
+		if err != nil {
+			return fmt.Errorf("please enter a number")
+		}
+		return check(value)
 	}
-	return nil
 }
 
-type IntValidator func(string) error
-
 func PromptInt(question string, validator IntValidator) (int, error) {
 	prompt := promptui.Prompt{
 		Label: question,
@@ -47,6 +39,19 @@ func PromptInt(question string, validator IntValidator) (int, error) {
 	return value, err
 }
 
+// PromptString asks for free text, which may be left empty
+func PromptString(question string) (string, error) {
+	prompt := promptui.Prompt{Label: question}
+	result, err := prompt.Run()
+	if err != nil {
+		if errors.Is(err, promptui.ErrInterrupt) {
+			return "", fmt.Errorf("interrupted by user")
+		}
+		return "", fmt.Errorf("prompt failed: %w", err)
+	}
+	return strings.TrimSpace(result), nil
+}
+
 func PromptSelect(question string, items []string) (int, error) {
 	prompt := promptui.Select{
 		Label: question,
@@ -61,3 +66,21 @@ func PromptSelect(question string, items []string) (int, error) {
 	}
 	return idx, nil
 }
+
+func PromptConfirm(question string) (bool, error) {
+	prompt := promptui.Prompt{
+		Label:     question,
+		IsConfirm: true,
+	}
+	_, err := prompt.Run()
+	if err != nil {
+		if errors.Is(err, promptui.ErrAbort) {
+			return false, nil
+		}
+		if errors.Is(err, promptui.ErrInterrupt) {
+			return false, fmt.Errorf("interrupted by user")
+		}
+		return false, fmt.Errorf("prompt failed: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,15 @@
+package common
+
+import "strings"
+
+// SplitCSV splits a comma separated flag value into trimmed, lower-cased, non-empty items
+func SplitCSV(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -1,42 +1,80 @@
 package config
 
 import (
+	"dsacli/clock"
+	"dsacli/common"
 	"fmt"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 const AppName = "dsacli"
 const DefaultDBFileName = "dsacli.db"
+const DefaultAPITokenFileName = "api_token"
 
 type Config struct {
-	DbPath string
+	DbPath       string
+	APITokenPath string
+	SettingsPath string
+	Settings     Settings
+	// Clock is used for every "now" and "today" decision so day boundaries can be tested
+	Clock clock.Clock
+	// Rand picks between equally good questions; seed it to make plans reproducible
+	Rand *rand.Rand
+	// Seed is the seed Rand was created with, recorded in the decision trace of each plan
+	Seed int64
 }
 
 func NewConfig(dbPath string) Config {
+	seed := time.Now().UnixNano()
 	return Config{
-		DbPath: dbPath,
+		DbPath:       dbPath,
+		APITokenPath: filepath.Join(filepath.Dir(dbPath), DefaultAPITokenFileName),
+		SettingsPath: filepath.Join(filepath.Dir(dbPath), DefaultSettingsFileName),
+		Settings:     DefaultSettings(),
+		Clock:        clock.System{},
+		Rand:         common.NewRand(seed),
+		Seed:         seed,
 	}
 }
 
 func NewDefaultConfig() Config {
-	dbPath, err := getDBPath(DefaultDBFileName)
+	dbPath, err := getAppFilePath(DefaultDBFileName)
 	if err != nil {
 		panic(err)
 	}
 
+	tokenPath, err := getAppFilePath(DefaultAPITokenFileName)
+	if err != nil {
+		panic(err)
+	}
+
+	settingsPath, err := getAppFilePath(DefaultSettingsFileName)
+	if err != nil {
+		panic(err)
+	}
+
+	seed := time.Now().UnixNano()
 	return Config{
-		DbPath: dbPath,
+		DbPath:       dbPath,
+		APITokenPath: tokenPath,
+		SettingsPath: settingsPath,
+		Settings:     DefaultSettings(),
+		Clock:        clock.System{},
+		Rand:         common.NewRand(seed),
+		Seed:         seed,
 	}
 }
 
-// SqLite3 file is created at ~/.dsacli/dsacli.db
-func getDBPath(dbFileName string) (string, error) {
+// App files are created inside ~/.dsacli, e.g. the SqLite3 file at ~/.dsacli/dsacli.db
+func getAppFilePath(fileName string) (string, error) {
 	appDir, err := getAppDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(appDir, dbFileName), nil
+	return filepath.Join(appDir, fileName), nil
 }
 
 // Creates a folder ~/.dsacli
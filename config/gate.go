@@ -0,0 +1,98 @@
+package config
+
+import (
+	"dsacli/types"
+	"fmt"
+	"strconv"
+)
+
+// Gate is what a difficulty tier takes before the next tier unlocks. Every criterion that
+// isn't 0 has to be met; a gate without any criteria is always open.
+type Gate struct {
+	// MasteryPercent is the share of the tier's questions that must be mastered, exclusive
+	MasteryPercent float64 `json:"mastery_percent"`
+	// MinAttempts is how many attempts must have been made on the tier's questions
+	MinAttempts int `json:"min_attempts"`
+	// TagCoverage is the share of every tag's questions in the tier that must be attempted
+	TagCoverage float64 `json:"tag_coverage"`
+}
+
+// DefaultGate unlocks the next tier once more than half of a tier is mastered
+func DefaultGate() Gate {
+	return Gate{MasteryPercent: 50}
+}
+
+// Gates holds the gate of every difficulty tier
+type Gates struct {
+	Easy   Gate `json:"easy"`
+	Medium Gate `json:"medium"`
+	Hard   Gate `json:"hard"`
+}
+
+// For returns the gate of the given difficulty
+func (g Gates) For(difficulty string) Gate {
+	gate, _ := g.gate(difficulty)
+	return *gate
+}
+
+func (g *Gates) gate(difficulty string) (*Gate, bool) {
+	switch difficulty {
+	case types.DifficultyEasy:
+		return &g.Easy, true
+	case types.DifficultyMedium:
+		return &g.Medium, true
+	case types.DifficultyHard:
+		return &g.Hard, true
+	}
+	return &Gate{}, false
+}
+
+// Validate checks the criteria of every gate
+func (g Gates) Validate() error {
+	for _, difficulty := range types.Difficulties {
+		gate := g.For(difficulty)
+		if gate.MasteryPercent < 0 || gate.MasteryPercent >= 100 {
+			return fmt.Errorf("%s-gate-mastery must be between 0 and 100 (exclusive)", difficulty)
+		}
+		if gate.MinAttempts < 0 {
+			return fmt.Errorf("%s-gate-min-attempts must be >= 0", difficulty)
+		}
+		if gate.TagCoverage < 0 || gate.TagCoverage > 100 {
+			return fmt.Errorf("%s-gate-tag-coverage must be between 0 and 100", difficulty)
+		}
+	}
+	return nil
+}
+
+// Register a key per criterion and tier, e.g. "medium-gate-mastery"
+func init() {
+	for _, difficulty := range types.Difficulties {
+		difficulty := difficulty
+		gate := func(s *Settings) *Gate {
+			g, _ := s.Gates.gate(difficulty)
+			return g
+		}
+
+		settings[difficulty+"-gate-mastery"] = setting{
+			description: fmt.Sprintf("Percent of %s questions to master before the next tier unlocks (0 to not require it)", difficulty),
+			get:         func(s Settings) string { return strconv.FormatFloat(gate(&s).MasteryPercent, 'f', -1, 64) },
+			set: func(s *Settings, value string) error {
+				return setFloat(&gate(s).MasteryPercent, value)
+			},
+		}
+		settings[difficulty+"-gate-min-attempts"] = setting{
+			description: fmt.Sprintf("Attempts to make on %s questions before the next tier unlocks", difficulty),
+			get:         func(s Settings) string { return strconv.Itoa(gate(&s).MinAttempts) },
+			set: func(s *Settings, value string) error {
+				return setInt(&gate(s).MinAttempts, value)
+			},
+		}
+		settings[difficulty+"-gate-tag-coverage"] = setting{
+			description: fmt.Sprintf("Percent of each tag's %s questions to attempt before the next tier unlocks", difficulty),
+			get:         func(s Settings) string { return strconv.FormatFloat(gate(&s).TagCoverage, 'f', -1, 64) },
+			set: func(s *Settings, value string) error {
+				return setFloat(&gate(s).TagCoverage, value)
+			},
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package config
+
+import (
+	"dsacli/clock"
+	"dsacli/common"
+	"dsacli/types"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DefaultSettingsFileName = "config.json"
+
+// Settings are the user's preferences, stored as JSON in ~/.dsacli/config.json
+type Settings struct {
+	// Timezone is an IANA name such as "Europe/Berlin"; empty means the system time zone
+	Timezone string `json:"timezone,omitempty"`
+	// DayStartHour is the hour a new practice day starts at, e.g. 4 to keep late sessions in the previous day
+	DayStartHour int `json:"day_start_hour"`
+	// CarryOverCap is the most unfinished questions from missed days rolled into a new plan
+	CarryOverCap int `json:"carry_over_cap"`
+	// BudgetMinutes is the daily practice time to fill with questions; 0 plans a fixed two questions
+	BudgetMinutes int `json:"budget_minutes"`
+	// WeekendBudgetMinutes replaces BudgetMinutes on Saturdays and Sundays when it isn't 0
+	WeekendBudgetMinutes int `json:"weekend_budget_minutes"`
+	// NewRatio is the share of the budget spent on questions never attempted before
+	NewRatio float64 `json:"new_ratio"`
+	// Gates decide when each difficulty tier unlocks the next one
+	Gates Gates `json:"gates"`
+	// GateLeak is the share of new questions taken from the next tier before its gate opens
+	GateLeak float64 `json:"gate_leak"`
+	// TimeTargets are the expected solve times the time part of the p-score is relative to
+	TimeTargets TimeTargets `json:"time_targets"`
+	// LearnTimeTargets replaces the time targets by the median of the user's own solves once there are enough
+	LearnTimeTargets bool `json:"learn_time_targets"`
+	// LeechThreshold is the number of failed recalls that turns a question into a leech; 0 disables detection
+	LeechThreshold int `json:"leech_threshold"`
+	// FocusCompanies is a comma separated list of the companies new questions are biased towards
+	FocusCompanies string `json:"focus_companies,omitempty"`
+	// ExcludePremium leaves the questions locked behind a paid subscription out of scheduling
+	ExcludePremium bool `json:"exclude_premium"`
+	// ExcludePlatforms is a comma separated list of the platforms left out of scheduling
+	ExcludePlatforms string `json:"exclude_platforms,omitempty"`
+}
+
+// DefaultSettings are used for anything missing from the settings file
+func DefaultSettings() Settings {
+	return Settings{
+		CarryOverCap:   2,
+		NewRatio:       0.5,
+		Gates:          Gates{Easy: DefaultGate(), Medium: DefaultGate(), Hard: DefaultGate()},
+		TimeTargets:    DefaultTimeTargets(),
+		LeechThreshold: 4,
+	}
+}
+
+// Budget returns the practice time in minutes for the given day, 0 meaning no budget
+func (s Settings) Budget(day time.Time) int {
+	weekend := day.Weekday() == time.Saturday || day.Weekday() == time.Sunday
+	if weekend && s.WeekendBudgetMinutes > 0 {
+		return s.WeekendBudgetMinutes
+	}
+	return s.BudgetMinutes
+}
+
+// Focus returns the companies new questions are biased towards
+func (s Settings) Focus() []string {
+	return common.SplitCSV(s.FocusCompanies)
+}
+
+// ExcludedPlatforms returns the platforms left out of scheduling
+func (s Settings) ExcludedPlatforms() []string {
+	return common.SplitCSV(s.ExcludePlatforms)
+}
+
+// LoadSettings reads the settings file, returning the defaults if it doesn't exist yet
+func LoadSettings(path string) (Settings, error) {
+	settings := DefaultSettings()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return settings, fmt.Errorf("reading settings: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return settings, fmt.Errorf("parsing settings %s: %w", path, err)
+	}
+	if err := settings.Validate(); err != nil {
+		return settings, fmt.Errorf("invalid settings in %s: %w", path, err)
+	}
+	return settings, nil
+}
+
+// SaveSettings writes the settings file
+func SaveSettings(path string, settings Settings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Validate checks every setting
+func (s Settings) Validate() error {
+	if _, err := loadLocation(s.Timezone); err != nil {
+		return err
+	}
+	if s.DayStartHour < 0 || s.DayStartHour > 23 {
+		return fmt.Errorf("day-start-hour must be between 0 and 23")
+	}
+	if s.CarryOverCap < 0 {
+		return fmt.Errorf("carry-over-cap must be >= 0")
+	}
+	if s.BudgetMinutes < 0 || s.WeekendBudgetMinutes < 0 {
+		return fmt.Errorf("budgets must be >= 0 minutes")
+	}
+	if s.NewRatio < 0 || s.NewRatio > 1 {
+		return fmt.Errorf("new-ratio must be between 0 and 1")
+	}
+	if err := s.Gates.Validate(); err != nil {
+		return err
+	}
+	if s.GateLeak < 0 || s.GateLeak > 1 {
+		return fmt.Errorf("gate-leak must be between 0 and 1")
+	}
+	if err := s.TimeTargets.Validate(); err != nil {
+		return err
+	}
+	if s.LeechThreshold < 0 {
+		return fmt.Errorf("leech-threshold must be >= 0")
+	}
+	for _, platform := range s.ExcludedPlatforms() {
+		if !types.IsValidPlatform(platform) {
+			return fmt.Errorf("unknown platform %q in exclude-platforms, expected one of %s", platform, strings.Join(types.Platforms, ", "))
+		}
+	}
+	return nil
+}
+
+// Calendar returns the practice day calendar for the configured time zone and day start.
+// The settings are expected to be valid; an unknown time zone falls back to the system one.
+func (s Settings) Calendar() clock.Calendar {
+	loc, err := loadLocation(s.Timezone)
+	if err != nil {
+		loc = time.Local
+	}
+	return clock.Calendar{Location: loc, StartHour: s.DayStartHour}
+}
+
+func loadLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", timezone)
+	}
+	return loc, nil
+}
+
+// setting describes a key that can be read and changed with "dsacli config"
+type setting struct {
+	description string
+	get         func(s Settings) string
+	set         func(s *Settings, value string) error
+}
+
+var settings = map[string]setting{
+	"timezone": {
+		description: "IANA time zone used to decide what day it is (empty for the system time zone)",
+		get:         func(s Settings) string { return s.Timezone },
+		set: func(s *Settings, value string) error {
+			s.Timezone = value
+			return nil
+		},
+	},
+	"day-start-hour": {
+		description: "Hour (0-23) at which a new practice day starts",
+		get:         func(s Settings) string { return strconv.Itoa(s.DayStartHour) },
+		set: func(s *Settings, value string) error {
+			return setInt(&s.DayStartHour, value)
+		},
+	},
+	"carry-over-cap": {
+		description: "Most unfinished questions from missed days added to a new day's plan (0 to disable)",
+		get:         func(s Settings) string { return strconv.Itoa(s.CarryOverCap) },
+		set: func(s *Settings, value string) error {
+			return setInt(&s.CarryOverCap, value)
+		},
+	},
+	"budget-minutes": {
+		description: "Daily practice time in minutes to fill with questions (0 for a fixed two questions a day)",
+		get:         func(s Settings) string { return strconv.Itoa(s.BudgetMinutes) },
+		set: func(s *Settings, value string) error {
+			return setInt(&s.BudgetMinutes, value)
+		},
+	},
+	"weekend-budget-minutes": {
+		description: "Practice time on Saturdays and Sundays (0 to use budget-minutes)",
+		get:         func(s Settings) string { return strconv.Itoa(s.WeekendBudgetMinutes) },
+		set: func(s *Settings, value string) error {
+			return setInt(&s.WeekendBudgetMinutes, value)
+		},
+	},
+	"new-ratio": {
+		description: "Share (0-1) of the budget spent on new questions, the rest goes to reviews",
+		get:         func(s Settings) string { return strconv.FormatFloat(s.NewRatio, 'f', -1, 64) },
+		set: func(s *Settings, value string) error {
+			return setFloat(&s.NewRatio, value)
+		},
+	},
+	"gate-leak": {
+		description: "Share (0-1) of new questions taken from the next tier before its gate opens",
+		get:         func(s Settings) string { return strconv.FormatFloat(s.GateLeak, 'f', -1, 64) },
+		set: func(s *Settings, value string) error {
+			return setFloat(&s.GateLeak, value)
+		},
+	},
+	"leech-threshold": {
+		description: "Failed recalls after which a question is flagged as a leech (0 to disable)",
+		get:         func(s Settings) string { return strconv.Itoa(s.LeechThreshold) },
+		set: func(s *Settings, value string) error {
+			return setInt(&s.LeechThreshold, value)
+		},
+	},
+	"focus-companies": {
+		description: "Comma separated companies new questions are picked from first, e.g. google,amazon (empty for none)",
+		get:         func(s Settings) string { return s.FocusCompanies },
+		set: func(s *Settings, value string) error {
+			s.FocusCompanies = strings.Join(common.SplitCSV(value), ",")
+			return nil
+		},
+	},
+	"exclude-premium": {
+		description: "Leave questions locked behind a paid subscription out of scheduling (true/false)",
+		get:         func(s Settings) string { return strconv.FormatBool(s.ExcludePremium) },
+		set: func(s *Settings, value string) error {
+			return setBool(&s.ExcludePremium, value)
+		},
+	},
+	"exclude-platforms": {
+		description: "Comma separated platforms left out of scheduling, e.g. hackerrank,codeforces (empty for none)",
+		get:         func(s Settings) string { return s.ExcludePlatforms },
+		set: func(s *Settings, value string) error {
+			s.ExcludePlatforms = strings.Join(common.SplitCSV(value), ",")
+			return nil
+		},
+	},
+	"learn-time-targets": {
+		description: "Use the median of your own solves of a difficulty as its time target (true/false)",
+		get:         func(s Settings) string { return strconv.FormatBool(s.LearnTimeTargets) },
+		set: func(s *Settings, value string) error {
+			return setBool(&s.LearnTimeTargets, value)
+		},
+	},
+}
+
+// SettingKeys returns the names of all settings in alphabetical order
+func SettingKeys() []string {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DescribeSetting returns the help text of a setting
+func DescribeSetting(key string) string {
+	return settings[key].description
+}
+
+// Get returns the value of a setting formatted as a string
+func (s Settings) Get(key string) (string, error) {
+	def, ok := settings[key]
+	if !ok {
+		return "", fmt.Errorf("unknown setting %q", key)
+	}
+	return def.get(s), nil
+}
+
+// Set parses and validates value and stores it in the setting
+func (s *Settings) Set(key, value string) error {
+	def, ok := settings[key]
+	if !ok {
+		return fmt.Errorf("unknown setting %q", key)
+	}
+
+	updated := *s
+	if err := def.set(&updated, value); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+	*s = updated
+	return nil
+}
+
+// Reset restores a setting to its default value
+func (s *Settings) Reset(key string) error {
+	def, ok := settings[key]
+	if !ok {
+		return fmt.Errorf("unknown setting %q", key)
+	}
+	return def.set(s, def.get(DefaultSettings()))
+}
+
+func setFloat(target *float64, value string) error {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("%q is not a number", value)
+	}
+	*target = f
+	return nil
+}
+
+func setBool(target *bool, value string) error {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("%q is not true or false", value)
+	}
+	*target = b
+	return nil
+}
+
+func setInt(target *int, value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("%q is not a number", value)
+	}
+	*target = n
+	return nil
+}
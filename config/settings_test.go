@@ -0,0 +1,133 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSettingsSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr bool
+	}{
+		{name: "Valid timezone", key: "timezone", value: "Europe/Berlin"},
+		{name: "Unknown timezone", key: "timezone", value: "Mars/Olympus", wantErr: true},
+		{name: "Valid day start", key: "day-start-hour", value: "4"},
+		{name: "Day start out of range", key: "day-start-hour", value: "24", wantErr: true},
+		{name: "Day start not a number", key: "day-start-hour", value: "four", wantErr: true},
+		{name: "Valid carry over cap", key: "carry-over-cap", value: "0"},
+		{name: "Negative carry over cap", key: "carry-over-cap", value: "-1", wantErr: true},
+		{name: "Valid budget", key: "budget-minutes", value: "90"},
+		{name: "Negative weekend budget", key: "weekend-budget-minutes", value: "-30", wantErr: true},
+		{name: "Valid new ratio", key: "new-ratio", value: "0.3"},
+		{name: "New ratio above 1", key: "new-ratio", value: "1.5", wantErr: true},
+		{name: "Valid gate", key: "medium-gate-mastery", value: "70"},
+		{name: "Gate mastery of 100", key: "easy-gate-mastery", value: "100", wantErr: true},
+		{name: "Valid gate attempts", key: "hard-gate-min-attempts", value: "20"},
+		{name: "Gate leak above 1", key: "gate-leak", value: "2", wantErr: true},
+		{name: "Valid time target", key: "hard-time-target", value: "60"},
+		{name: "Time target of 0", key: "easy-time-target", value: "0", wantErr: true},
+		{name: "Valid leech threshold", key: "leech-threshold", value: "6"},
+		{name: "Negative leech threshold", key: "leech-threshold", value: "-1", wantErr: true},
+		{name: "Learn time targets", key: "learn-time-targets", value: "true"},
+		{name: "Learn time targets not a bool", key: "learn-time-targets", value: "maybe", wantErr: true},
+		{name: "Focus companies", key: "focus-companies", value: "amazon,google"},
+		{name: "Exclude premium", key: "exclude-premium", value: "true"},
+		{name: "Exclude platforms", key: "exclude-platforms", value: "hackerrank,codeforces"},
+		{name: "Exclude unknown platform", key: "exclude-platforms", value: "topcoder", wantErr: true},
+		{name: "Unknown key", key: "colour", value: "blue", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := DefaultSettings()
+			err := settings.Set(tt.key, tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Set(%q, %q) expected error", tt.key, tt.value)
+				}
+				if settings != DefaultSettings() {
+					t.Errorf("Set(%q, %q) changed the settings despite the error", tt.key, tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Set(%q, %q) unexpected error: %v", tt.key, tt.value, err)
+			}
+			if got, _ := settings.Get(tt.key); got != tt.value {
+				t.Errorf("Get(%q) = %q, want %q", tt.key, got, tt.value)
+			}
+		})
+	}
+}
+
+func TestSettingsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultSettingsFileName)
+
+	settings, err := LoadSettings(path)
+	if err != nil {
+		t.Fatalf("LoadSettings() of a missing file unexpected error: %v", err)
+	}
+	if settings != DefaultSettings() {
+		t.Errorf("Expected defaults for a missing file, got %+v", settings)
+	}
+
+	settings.Timezone = "UTC"
+	settings.DayStartHour = 4
+	if err := SaveSettings(path, settings); err != nil {
+		t.Fatalf("SaveSettings() unexpected error: %v", err)
+	}
+
+	loaded, err := LoadSettings(path)
+	if err != nil {
+		t.Fatalf("LoadSettings() unexpected error: %v", err)
+	}
+	if loaded != settings {
+		t.Errorf("LoadSettings() = %+v, want %+v", loaded, settings)
+	}
+}
+
+func TestLoadSettingsPartialGate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultSettingsFileName)
+	if err := os.WriteFile(path, []byte(`{"gates": {"medium": {"min_attempts": 10}}}`), 0644); err != nil {
+		t.Fatalf("Failed to write settings: %v", err)
+	}
+
+	settings, err := LoadSettings(path)
+	if err != nil {
+		t.Fatalf("LoadSettings() unexpected error: %v", err)
+	}
+	expected := Gate{MasteryPercent: 50, MinAttempts: 10}
+	if got := settings.Gates.For("medium"); got != expected {
+		t.Errorf("Gates.For(medium) = %+v, want %+v", got, expected)
+	}
+}
+
+func TestSettingsBudget(t *testing.T) {
+	friday := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+	saturday := friday.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name     string
+		settings Settings
+		day      time.Time
+		expected int
+	}{
+		{name: "No budget", settings: Settings{}, day: friday, expected: 0},
+		{name: "Weekday", settings: Settings{BudgetMinutes: 60, WeekendBudgetMinutes: 120}, day: friday, expected: 60},
+		{name: "Weekend", settings: Settings{BudgetMinutes: 60, WeekendBudgetMinutes: 120}, day: saturday, expected: 120},
+		{name: "Weekend falls back to weekday budget", settings: Settings{BudgetMinutes: 60}, day: saturday, expected: 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.settings.Budget(tt.day); got != tt.expected {
+				t.Errorf("Budget(%s) = %d, want %d", tt.day.Weekday(), got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"dsacli/types"
+	"fmt"
+	"strconv"
+)
+
+// TimeTargets are the minutes a solve of each difficulty is expected to take. A solve
+// within its target gets the full time score of the p-score.
+type TimeTargets struct {
+	Easy   int `json:"easy"`
+	Medium int `json:"medium"`
+	Hard   int `json:"hard"`
+}
+
+// DefaultTimeTargets keep medium at the 30 minutes every difficulty used to be held to
+func DefaultTimeTargets() TimeTargets {
+	return TimeTargets{Easy: 20, Medium: 30, Hard: 45}
+}
+
+// For returns the target of the given difficulty, the medium one for anything else
+func (t TimeTargets) For(difficulty string) int {
+	return *t.target(difficulty)
+}
+
+func (t *TimeTargets) target(difficulty string) *int {
+	switch difficulty {
+	case types.DifficultyEasy:
+		return &t.Easy
+	case types.DifficultyHard:
+		return &t.Hard
+	}
+	return &t.Medium
+}
+
+// Validate checks the target of every difficulty
+func (t TimeTargets) Validate() error {
+	for _, difficulty := range types.Difficulties {
+		if target := t.For(difficulty); target < 1 || target > 300 {
+			return fmt.Errorf("%s-time-target must be between 1 and 300 minutes", difficulty)
+		}
+	}
+	return nil
+}
+
+// Register a key per tier, e.g. "hard-time-target"
+func init() {
+	for _, difficulty := range types.Difficulties {
+		difficulty := difficulty
+		settings[difficulty+"-time-target"] = setting{
+			description: fmt.Sprintf("Minutes a %s question is expected to take; slower solves lower the p-score", difficulty),
+			get:         func(s Settings) string { return strconv.Itoa(s.TimeTargets.For(difficulty)) },
+			set: func(s *Settings, value string) error {
+				return setInt(s.TimeTargets.target(difficulty), value)
+			},
+		}
+	}
+}
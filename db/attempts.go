@@ -0,0 +1,82 @@
+package db
+
+import (
+	"dsacli/types"
+)
+
+func (d SQLDatabase) InsertAttempt(attempt types.Attempt) error {
+	res := d.db.Create(&attempt)
+	return res.Error
+}
+
+// UpdateAttempt saves every field of an existing attempt
+func (d SQLDatabase) UpdateAttempt(attempt types.Attempt) error {
+	return d.db.Save(&attempt).Error
+}
+
+// GetAttempts returns the whole attempt history, oldest first
+func (d SQLDatabase) GetAttempts() ([]types.Attempt, error) {
+	var attempts []types.Attempt
+	res := d.db.Order("completed_at ASC").Find(&attempts)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return attempts, nil
+}
+
+// GetRecentAttempts returns the latest attempts along with their questions, newest first
+func (d SQLDatabase) GetRecentAttempts(limit int) ([]types.AttemptWithQuestion, error) {
+	var attempts []types.Attempt
+	res := d.db.Order("completed_at DESC").Limit(limit).Find(&attempts)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	if len(attempts) == 0 {
+		return nil, nil
+	}
+
+	var questionIDs []uint
+	for _, a := range attempts {
+		questionIDs = append(questionIDs, a.QuestionID)
+	}
+
+	var questions []types.Question
+	res = d.db.Where("id IN ?", questionIDs).Find(&questions)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	questionMap := make(map[uint]types.Question)
+	for _, q := range questions {
+		questionMap[q.ID] = q
+	}
+
+	var result []types.AttemptWithQuestion
+	for _, a := range attempts {
+		result = append(result, types.AttemptWithQuestion{
+			Attempt:  a,
+			Question: questionMap[a.QuestionID],
+		})
+	}
+
+	return result, nil
+}
+
+// GetAttemptCountsByDate returns the number of attempts made on each day with at least one attempt
+func (d SQLDatabase) GetAttemptCountsByDate() (map[string]int, error) {
+	var rows []struct {
+		Date  string
+		Count int
+	}
+	res := d.db.Model(&types.Attempt{}).Select("date, COUNT(*) AS count").Group("date").Scan(&rows)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, r := range rows {
+		counts[r.Date] = r.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"dsacli/types"
+
+	"gorm.io/gorm"
+)
+
+// backlogQuery selects the plan entries before the given date that were neither
+// completed, carried over to a later plan nor dismissed
+func (d SQLDatabase) backlogQuery(before string) *gorm.DB {
+	return d.db.Model(&types.TodayQuestion{}).
+		Where("date < ? AND completed = ? AND carried_over = ? AND dismissed = ?", before, false, false, false)
+}
+
+// GetBacklog returns the unfinished questions of the plans before the given date, newest plan first
+func (d SQLDatabase) GetBacklog(before string) ([]types.TodayQuestionWithStatus, error) {
+	var entries []types.TodayQuestion
+	res := d.backlogQuery(before).Order("date DESC, id").Find(&entries)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	var questionIDs []uint
+	for _, tq := range entries {
+		questionIDs = append(questionIDs, tq.QuestionID)
+	}
+
+	var questions []types.Question
+	res = d.db.Where("id IN ?", questionIDs).Find(&questions)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	questionsByID := make(map[uint]types.Question)
+	for _, q := range questions {
+		questionsByID[q.ID] = q
+	}
+
+	var result []types.TodayQuestionWithStatus
+	for _, tq := range entries {
+		q, ok := questionsByID[tq.QuestionID]
+		if !ok {
+			continue
+		}
+		result = append(result, types.TodayQuestionWithStatus{
+			Question:    q,
+			Date:        tq.Date,
+			CarriedFrom: tq.CarriedFrom,
+			Pick:        tq.Pick,
+		})
+	}
+	return result, nil
+}
+
+// CarryOverTodayQuestions moves unfinished entries of earlier plans into the plan of the given date
+func (d SQLDatabase) CarryOverTodayQuestions(date string, missed []types.TodayQuestionWithStatus) error {
+	if len(missed) == 0 {
+		return nil
+	}
+
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		for _, m := range missed {
+			res := tx.Model(&types.TodayQuestion{}).
+				Where("question_id = ? AND date = ?", m.Question.ID, m.Date).
+				Update("carried_over", true)
+			if res.Error != nil {
+				return res.Error
+			}
+
+			entry := types.TodayQuestion{Date: date, QuestionID: m.Question.ID, CarriedFrom: m.Date, Pick: m.Pick}
+			if err := tx.Create(&entry).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DismissBacklog clears the unfinished entries of the plans before the given date, or only
+// of the plan of onDate when it isn't empty, and returns how many were cleared
+func (d SQLDatabase) DismissBacklog(before, onDate string) (int, error) {
+	query := d.backlogQuery(before)
+	if onDate != "" {
+		query = query.Where("date = ?", onDate)
+	}
+	res := query.Update("dismissed", true)
+	return int(res.RowsAffected), res.Error
+}
@@ -13,12 +13,43 @@ const (
 type Database interface {
 	GetQuestionsByDifficulty(difficulty string) ([]types.Question, error)
 	GetAllQuestions() ([]types.Question, error)
+	GetActiveQuestions() ([]types.Question, error)
+	FilterQuestions(filter QuestionFilter) ([]types.Question, error)
 	FindQuestionByID(id uint) (types.Question, error)
 	UpdateQuestion(question types.Question) error
 	InsertQuestions(questions []types.Question) error
-	GetTodayQuestions() ([]types.Question, []types.TodayQuestion, error)
-	InsertTodayQuestions(questions []types.Question) error
-	GetTodayQuestionsWithStatus() ([]types.TodayQuestionWithStatus, error)
-	MarkTodayQuestionCompleted(questionID uint) error
+	SeedQuestions(questions []types.Question, link func(inserted []types.Question) ([]types.QuestionDependency, []types.QuestionContent)) error
+	DeleteQuestion(id uint) error
+	GetDependencies() ([]types.QuestionDependency, error)
+	InsertDependencies(dependencies []types.QuestionDependency) error
+	GetQuestionContent(questionID uint) (types.QuestionContent, error)
+	SaveQuestionContents(contents []types.QuestionContent) error
+	ArchiveQuestion(id uint) error
+	UnarchiveQuestion(id uint) error
+	GetTodayQuestions(date string) ([]types.Question, []types.TodayQuestion, error)
+	InsertTodayQuestions(date string, questions []types.Question, picks map[uint]types.Pick) error
+	GetTodayQuestionsWithStatus(date string) ([]types.TodayQuestionWithStatus, error)
+	GetQuestionPlans(questionID uint) ([]types.TodayQuestion, error)
+	MarkTodayQuestionCompleted(questionID uint, dates []string) (string, error)
+	GetBacklog(before string) ([]types.TodayQuestionWithStatus, error)
+	CarryOverTodayQuestions(date string, missed []types.TodayQuestionWithStatus) error
+	DismissBacklog(before, onDate string) (int, error)
+	GetPause() (types.Pause, bool, error)
+	SetPause(pause types.Pause) error
+	ClearPause() error
+	ShiftDueDates(days int) error
+	GetGoal() (types.Goal, bool, error)
+	SetGoal(goal types.Goal) error
+	ClearGoal() error
 	GetAllAttemptedQuestions() ([]types.Question, error)
+	InsertAttempt(attempt types.Attempt) error
+	UpdateAttempt(attempt types.Attempt) error
+	GetAttempts() ([]types.Attempt, error)
+	GetRecentAttempts(limit int) ([]types.AttemptWithQuestion, error)
+	GetAttemptCountsByDate() (map[string]int, error)
+	InsertMockSession(session *types.MockSession) error
+	UpdateMockSession(session types.MockSession) error
+	GetSessionAttempts(sessionID uint) ([]types.Attempt, error)
+	MigrationApplied(name string) (bool, error)
+	MarkMigrationApplied(name string) error
 }
@@ -0,0 +1,201 @@
+// Package dbtest provides an in-memory db.Database implementation for tests
+package dbtest
+
+import (
+	"dsacli/db"
+	"dsacli/types"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// MockDatabase implements the Database interface for testing
+type MockDatabase struct {
+	QuestionsByDifficulty      map[string][]types.Question
+	AllQuestions               []types.Question
+	TodayQuestionsWithStatus   []types.TodayQuestionWithStatus
+	ShouldReturnError          bool
+	ErrorMessage               string
+	InsertTodayQuestionsCalled bool
+	InsertedQuestions          []types.Question
+	InsertedPicks              map[uint]types.Pick
+	Dependencies               []types.QuestionDependency
+	Attempts                   []types.Attempt
+}
+
+func (m *MockDatabase) GetQuestionsByDifficulty(difficulty string) ([]types.Question, error) {
+	if m.ShouldReturnError {
+		return nil, errors.New(m.ErrorMessage)
+	}
+	return m.QuestionsByDifficulty[difficulty], nil
+}
+
+func (m *MockDatabase) GetAllQuestions() ([]types.Question, error) {
+	if m.ShouldReturnError {
+		return nil, errors.New(m.ErrorMessage)
+	}
+	return m.AllQuestions, nil
+}
+
+func (m *MockDatabase) GetActiveQuestions() ([]types.Question, error) {
+	return m.GetAllQuestions()
+}
+
+func (m *MockDatabase) GetTodayQuestionsWithStatus(date string) ([]types.TodayQuestionWithStatus, error) {
+	if m.ShouldReturnError {
+		return nil, errors.New(m.ErrorMessage)
+	}
+	return m.TodayQuestionsWithStatus, nil
+}
+
+func (m *MockDatabase) InsertTodayQuestions(date string, questions []types.Question, picks map[uint]types.Pick) error {
+	m.InsertTodayQuestionsCalled = true
+	m.InsertedQuestions = questions
+	m.InsertedPicks = picks
+	if m.ShouldReturnError {
+		return errors.New(m.ErrorMessage)
+	}
+	return nil
+}
+
+func (m *MockDatabase) GetDependencies() ([]types.QuestionDependency, error) {
+	if m.ShouldReturnError {
+		return nil, errors.New(m.ErrorMessage)
+	}
+	return m.Dependencies, nil
+}
+
+func (m *MockDatabase) InsertDependencies(dependencies []types.QuestionDependency) error {
+	m.Dependencies = append(m.Dependencies, dependencies...)
+	return nil
+}
+
+// DeleteQuestion removes the question along with its prerequisites and attempts, like the SQL database
+func (m *MockDatabase) DeleteQuestion(id uint) error {
+	var questions []types.Question
+	for _, q := range m.AllQuestions {
+		if q.ID != id {
+			questions = append(questions, q)
+		}
+	}
+	m.AllQuestions = questions
+
+	var dependencies []types.QuestionDependency
+	for _, d := range m.Dependencies {
+		if d.QuestionID != id && d.PrerequisiteID != id {
+			dependencies = append(dependencies, d)
+		}
+	}
+	m.Dependencies = dependencies
+
+	var attempts []types.Attempt
+	for _, a := range m.Attempts {
+		if a.QuestionID != id {
+			attempts = append(attempts, a)
+		}
+	}
+	m.Attempts = attempts
+	return nil
+}
+
+func (m *MockDatabase) GetAttempts() ([]types.Attempt, error) {
+	return m.Attempts, nil
+}
+
+// Unused methods for interface compliance
+func (m *MockDatabase) FilterQuestions(filter db.QuestionFilter) ([]types.Question, error) {
+	return nil, nil
+}
+func (m *MockDatabase) GetQuestionContent(questionID uint) (types.QuestionContent, error) {
+	return types.QuestionContent{}, gorm.ErrRecordNotFound
+}
+func (m *MockDatabase) SaveQuestionContents(contents []types.QuestionContent) error {
+	return nil
+}
+func (m *MockDatabase) FindQuestionByID(id uint) (types.Question, error) {
+	return types.Question{}, nil
+}
+func (m *MockDatabase) UpdateQuestion(question types.Question) error {
+	return nil
+}
+func (m *MockDatabase) InsertQuestions(questions []types.Question) error {
+	return nil
+}
+func (m *MockDatabase) SeedQuestions(questions []types.Question, link func(inserted []types.Question) ([]types.QuestionDependency, []types.QuestionContent)) error {
+	return nil
+}
+func (m *MockDatabase) ArchiveQuestion(id uint) error {
+	return nil
+}
+func (m *MockDatabase) UnarchiveQuestion(id uint) error {
+	return nil
+}
+func (m *MockDatabase) GetTodayQuestions(date string) ([]types.Question, []types.TodayQuestion, error) {
+	return nil, nil, nil
+}
+func (m *MockDatabase) GetQuestionPlans(questionID uint) ([]types.TodayQuestion, error) {
+	return nil, nil
+}
+func (m *MockDatabase) MarkTodayQuestionCompleted(questionID uint, dates []string) (string, error) {
+	return "", nil
+}
+func (m *MockDatabase) GetBacklog(before string) ([]types.TodayQuestionWithStatus, error) {
+	return nil, nil
+}
+func (m *MockDatabase) CarryOverTodayQuestions(date string, missed []types.TodayQuestionWithStatus) error {
+	return nil
+}
+func (m *MockDatabase) DismissBacklog(before, onDate string) (int, error) {
+	return 0, nil
+}
+func (m *MockDatabase) GetPause() (types.Pause, bool, error) {
+	return types.Pause{}, false, nil
+}
+func (m *MockDatabase) SetPause(pause types.Pause) error {
+	return nil
+}
+func (m *MockDatabase) ClearPause() error {
+	return nil
+}
+func (m *MockDatabase) ShiftDueDates(days int) error {
+	return nil
+}
+func (m *MockDatabase) GetGoal() (types.Goal, bool, error) {
+	return types.Goal{}, false, nil
+}
+func (m *MockDatabase) SetGoal(goal types.Goal) error {
+	return nil
+}
+func (m *MockDatabase) ClearGoal() error {
+	return nil
+}
+func (m *MockDatabase) GetAllAttemptedQuestions() ([]types.Question, error) {
+	return nil, nil
+}
+func (m *MockDatabase) InsertAttempt(attempt types.Attempt) error {
+	return nil
+}
+func (m *MockDatabase) UpdateAttempt(attempt types.Attempt) error {
+	return nil
+}
+func (m *MockDatabase) GetRecentAttempts(limit int) ([]types.AttemptWithQuestion, error) {
+	return nil, nil
+}
+func (m *MockDatabase) GetAttemptCountsByDate() (map[string]int, error) {
+	return nil, nil
+}
+func (m *MockDatabase) InsertMockSession(session *types.MockSession) error {
+	return nil
+}
+func (m *MockDatabase) UpdateMockSession(session types.MockSession) error {
+	return nil
+}
+func (m *MockDatabase) GetSessionAttempts(sessionID uint) ([]types.Attempt, error) {
+	return nil, nil
+}
+func (m *MockDatabase) MigrationApplied(name string) (bool, error) {
+	return true, nil
+}
+func (m *MockDatabase) MarkMigrationApplied(name string) error {
+	return nil
+}
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"dsacli/types"
+	"fmt"
+	"strings"
+)
+
+const (
+	SortByPScore   = "pscore"
+	SortByDue      = "due"
+	SortByAttempts = "attempts"
+	SortByName     = "name"
+)
+
+// SortOptions lists the supported values for QuestionFilter.Sort
+var SortOptions = []string{SortByPScore, SortByDue, SortByAttempts, SortByName}
+
+// likeEscaper escapes the LIKE wildcards so a tag such as "two_pointers" only matches itself
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// QuestionFilter describes which questions FilterQuestions returns and in which order.
+// Zero values mean "don't filter on this field".
+type QuestionFilter struct {
+	Difficulties    []string
+	Attempted       *bool
+	Mastered        *bool
+	Due             bool     // only questions whose review interval has elapsed
+	PScoreBelow     *float64 // only attempted questions with a last p-score below this value
+	Tag             string
+	URL             string // exact URL match, ignoring trailing slashes
+	Sort            string // one of SortOptions, defaults to id order
+	IncludeArchived bool
+	// IncludeSuspended also returns leeches set aside until they are resumed
+	IncludeSuspended bool
+	// IncludeExcluded also returns the premium questions and the ones on platforms excluded by the settings
+	IncludeExcluded bool
+}
+
+// FilterQuestions runs the filter as a single query against the question bank
+func (d SQLDatabase) FilterQuestions(filter QuestionFilter) ([]types.Question, error) {
+	query := d.db.Model(&types.Question{})
+
+	if !filter.IncludeArchived {
+		query = query.Where("archived = ?", false)
+	}
+	if !filter.IncludeSuspended {
+		query = query.Where("suspended = ?", false)
+	}
+	if !filter.IncludeExcluded {
+		query = d.withoutExcluded(query)
+	}
+	if len(filter.Difficulties) > 0 {
+		query = query.Where("difficulty IN ?", filter.Difficulties)
+	}
+	if filter.Attempted != nil {
+		query = query.Where("attempted = ?", *filter.Attempted)
+	}
+	if filter.Mastered != nil {
+		query = query.Where("mastered = ?", *filter.Mastered)
+	}
+	if filter.Due {
+		query = query.Where("attempted = ? AND last_reviewed IS NOT NULL AND julianday(last_reviewed) + review_interval + due_shift <= julianday(?)", true, d.clock.Now())
+	}
+	if filter.PScoreBelow != nil {
+		query = query.Where("attempted = ? AND last_p_score < ?", true, *filter.PScoreBelow)
+	}
+	if filter.Tag != "" {
+		// Tags are stored as a JSON array, so match the quoted tag to avoid partial matches
+		tag := likeEscaper.Replace(fmt.Sprintf("%q", strings.ToLower(filter.Tag)))
+		query = query.Where(`tags LIKE ? ESCAPE '\'`, "%"+tag+"%")
+	}
+
+	if filter.URL != "" {
+		query = query.Where("RTRIM(url, '/') = ?", strings.TrimRight(filter.URL, "/"))
+	}
+
+	switch filter.Sort {
+	case "":
+		query = query.Order("id")
+	case SortByPScore:
+		query = query.Order("last_p_score ASC").Order("id")
+	case SortByDue:
+		query = query.Order("CASE WHEN last_reviewed IS NULL THEN 1 ELSE 0 END").
+			Order("julianday(last_reviewed) + review_interval + due_shift ASC").Order("id")
+	case SortByAttempts:
+		query = query.Order("attempt_count DESC").Order("id")
+	case SortByName:
+		query = query.Order("name COLLATE NOCASE ASC")
+	default:
+		return nil, fmt.Errorf("invalid sort %q, expected one of %s", filter.Sort, strings.Join(SortOptions, ", "))
+	}
+
+	var questions []types.Question
+	if res := query.Find(&questions); res.Error != nil {
+		return nil, res.Error
+	}
+	return questions, nil
+}
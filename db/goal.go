@@ -0,0 +1,32 @@
+package db
+
+import (
+	"dsacli/types"
+
+	"gorm.io/gorm"
+)
+
+// GetGoal returns the interview goal, if any
+func (d SQLDatabase) GetGoal() (types.Goal, bool, error) {
+	var goal types.Goal
+	res := d.db.Order("id DESC").Limit(1).Find(&goal)
+	if res.Error != nil {
+		return types.Goal{}, false, res.Error
+	}
+	return goal, res.RowsAffected > 0, nil
+}
+
+// SetGoal replaces the interview goal
+func (d SQLDatabase) SetGoal(goal types.Goal) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&types.Goal{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&goal).Error
+	})
+}
+
+// ClearGoal removes the interview goal
+func (d SQLDatabase) ClearGoal() error {
+	return d.db.Where("1 = 1").Delete(&types.Goal{}).Error
+}
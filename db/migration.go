@@ -0,0 +1,19 @@
+package db
+
+import (
+	"dsacli/types"
+)
+
+// MigrationApplied reports whether the named data migration already ran on this database
+func (d SQLDatabase) MigrationApplied(name string) (bool, error) {
+	var count int64
+	if err := d.db.Model(&types.Migration{}).Where("name = ?", name).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// MarkMigrationApplied records that the named data migration ran
+func (d SQLDatabase) MarkMigrationApplied(name string) error {
+	return d.db.Create(&types.Migration{Name: name, AppliedAt: d.clock.Now()}).Error
+}
@@ -0,0 +1,25 @@
+package db
+
+import (
+	"dsacli/types"
+)
+
+// InsertMockSession saves a new mock session and sets its ID
+func (d SQLDatabase) InsertMockSession(session *types.MockSession) error {
+	return d.db.Create(session).Error
+}
+
+// UpdateMockSession saves the results of a mock session
+func (d SQLDatabase) UpdateMockSession(session types.MockSession) error {
+	return d.db.Save(&session).Error
+}
+
+// GetSessionAttempts returns the attempts made during a mock session, in order
+func (d SQLDatabase) GetSessionAttempts(sessionID uint) ([]types.Attempt, error) {
+	var attempts []types.Attempt
+	res := d.db.Where("session_id = ?", sessionID).Order("completed_at ASC, id ASC").Find(&attempts)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return attempts, nil
+}
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"dsacli/types"
+
+	"gorm.io/gorm"
+)
+
+// GetPause returns the current pause, if any
+func (d SQLDatabase) GetPause() (types.Pause, bool, error) {
+	var pause types.Pause
+	res := d.db.Order("id DESC").Limit(1).Find(&pause)
+	if res.Error != nil {
+		return types.Pause{}, false, res.Error
+	}
+	return pause, res.RowsAffected > 0, nil
+}
+
+// SetPause replaces the current pause
+func (d SQLDatabase) SetPause(pause types.Pause) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&types.Pause{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&pause).Error
+	})
+}
+
+// ClearPause ends the current pause
+func (d SQLDatabase) ClearPause() error {
+	return d.db.Where("1 = 1").Delete(&types.Pause{}).Error
+}
+
+// ShiftDueDates pushes the next review of every reviewed question back by the given number of
+// days, or pulls it forward for a negative number, without going before its review interval
+func (d SQLDatabase) ShiftDueDates(days int) error {
+	return d.db.Model(&types.Question{}).Where("last_reviewed IS NOT NULL").
+		Update("due_shift", gorm.Expr("MAX(due_shift + ?, 0)", days)).Error
+}
@@ -2,11 +2,14 @@ package db
 
 import (
 	"dsacli/types"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func (d SQLDatabase) GetQuestionsByDifficulty(difficulty string) ([]types.Question, error) {
 	var question []types.Question
-	res := d.db.Find(&question, "difficulty = ?", difficulty).Order("id")
+	res := d.schedulable().Order("id").Find(&question, "difficulty = ?", difficulty)
 	if res.Error != nil {
 		return nil, res.Error
 	}
@@ -23,9 +26,44 @@ func (d SQLDatabase) GetAllQuestions() ([]types.Question, error) {
 	return questions, nil
 }
 
+// GetActiveQuestions returns every question that can be scheduled, i.e. all questions that
+// are neither archived, suspended nor excluded by the settings
+func (d SQLDatabase) GetActiveQuestions() ([]types.Question, error) {
+	var questions []types.Question
+	res := d.schedulable().Find(&questions)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return questions, nil
+}
+
+// schedulable narrows a query down to the questions that can be scheduled: not archived, not
+// suspended, and neither premium nor on an excluded platform when the settings exclude those
+func (d SQLDatabase) schedulable() *gorm.DB {
+	return d.withoutExcluded(d.db.Where("archived = ? AND suspended = ?", false, false))
+}
+
+// withoutExcluded leaves the premium questions and the ones on excluded platforms out of the query
+// when the settings exclude them
+func (d SQLDatabase) withoutExcluded(query *gorm.DB) *gorm.DB {
+	if d.excludePremium {
+		query = query.Where("premium = ?", false)
+	}
+	if len(d.excludePlatforms) > 0 {
+		query = query.Where("platform NOT IN ?", d.excludePlatforms)
+	}
+	return query
+}
+
 func (d SQLDatabase) FindQuestionByID(id uint) (types.Question, error) {
-	q := types.Question{ID: id}
-	d.db.First(&q)
+	var q types.Question
+	res := d.db.Where("id = ?", id).Limit(1).Find(&q)
+	if res.Error != nil {
+		return types.Question{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return types.Question{}, gorm.ErrRecordNotFound
+	}
 	return q, nil
 }
 
@@ -45,6 +83,116 @@ func (d SQLDatabase) InsertQuestions(questions []types.Question) error {
 	return nil
 }
 
+// SeedQuestions inserts questions along with the prerequisites and statements link returns
+// for them once they have IDs, all in one transaction so a failed seed leaves nothing behind
+func (d SQLDatabase) SeedQuestions(questions []types.Question, link func(inserted []types.Question) ([]types.QuestionDependency, []types.QuestionContent)) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(questions).Error; err != nil {
+			return err
+		}
+		dependencies, contents := link(questions)
+		if len(dependencies) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&dependencies).Error; err != nil {
+				return err
+			}
+		}
+		if len(contents) > 0 {
+			if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&contents).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteQuestion removes a question along with every TodayQuestion row, prerequisite,
+// statement and attempt referencing it
+func (d SQLDatabase) DeleteQuestion(id uint) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("question_id = ?", id).Delete(&types.TodayQuestion{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("question_id = ? OR prerequisite_id = ?", id, id).Delete(&types.QuestionDependency{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("question_id = ?", id).Delete(&types.QuestionContent{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("question_id = ?", id).Delete(&types.Attempt{}).Error; err != nil {
+			return err
+		}
+
+		res := tx.Delete(&types.Question{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// ArchiveQuestion excludes a question from scheduling while keeping its review history
+func (d SQLDatabase) ArchiveQuestion(id uint) error {
+	return d.setArchived(id, true)
+}
+
+// UnarchiveQuestion makes an archived question available for scheduling again
+func (d SQLDatabase) UnarchiveQuestion(id uint) error {
+	return d.setArchived(id, false)
+}
+
+func (d SQLDatabase) setArchived(id uint, archived bool) error {
+	res := d.db.Model(&types.Question{}).Where("id = ?", id).Update("archived", archived)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetDependencies returns every prerequisite between questions
+func (d SQLDatabase) GetDependencies() ([]types.QuestionDependency, error) {
+	var dependencies []types.QuestionDependency
+	res := d.db.Order("question_id").Order("prerequisite_id").Find(&dependencies)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return dependencies, nil
+}
+
+// InsertDependencies adds prerequisites between questions, skipping the ones that already exist
+func (d SQLDatabase) InsertDependencies(dependencies []types.QuestionDependency) error {
+	if len(dependencies) == 0 {
+		return nil
+	}
+	return d.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&dependencies).Error
+}
+
+// GetQuestionContent returns the stored statement of a question, gorm.ErrRecordNotFound if it has none
+func (d SQLDatabase) GetQuestionContent(questionID uint) (types.QuestionContent, error) {
+	var content types.QuestionContent
+	res := d.db.Where("question_id = ?", questionID).Limit(1).Find(&content)
+	if res.Error != nil {
+		return types.QuestionContent{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return types.QuestionContent{}, gorm.ErrRecordNotFound
+	}
+	return content, nil
+}
+
+// SaveQuestionContents stores statements, replacing the ones the questions already had
+func (d SQLDatabase) SaveQuestionContents(contents []types.QuestionContent) error {
+	if len(contents) == 0 {
+		return nil
+	}
+	return d.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&contents).Error
+}
+
 func (d SQLDatabase) GetAllAttemptedQuestions() ([]types.Question, error) {
 	var questions []types.Question
 	res := d.db.Where("attempted = ?", true).Find(&questions)
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"dsacli/clock"
 	"dsacli/config"
 	"dsacli/types"
 
@@ -8,8 +9,15 @@ import (
 	"gorm.io/gorm"
 )
 
+// InMemoryPath opens a throwaway database that lives only as long as the process, e.g. for simulations
+const InMemoryPath = ":memory:"
+
 type SQLDatabase struct {
-	db *gorm.DB
+	db    *gorm.DB
+	clock clock.Clock
+	// Questions left out of scheduling by the settings
+	excludePremium   bool
+	excludePlatforms []string
 }
 
 func NewSQLDatabase(cfg config.Config) (Database, error) {
@@ -18,9 +26,41 @@ func NewSQLDatabase(cfg config.Config) (Database, error) {
 		return nil, err
 	}
 
-	if err := db.AutoMigrate(&types.Question{}, &types.TodayQuestion{}); err != nil {
+	if cfg.DbPath == InMemoryPath {
+		// Every connection to ":memory:" opens a separate empty database
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	if err := db.AutoMigrate(&types.Question{}, &types.TodayQuestion{}, &types.Attempt{}, &types.Pause{}, &types.Goal{}, &types.MockSession{}, &types.QuestionDependency{}, &types.QuestionContent{}, &types.Migration{}); err != nil {
+		return nil, err
+	}
+
+	if err := backfillPlatforms(db); err != nil {
 		return nil, err
 	}
 
-	return SQLDatabase{db: db}, nil
+	return SQLDatabase{
+		db:               db,
+		clock:            cfg.Clock,
+		excludePremium:   cfg.Settings.ExcludePremium,
+		excludePlatforms: cfg.Settings.ExcludedPlatforms(),
+	}, nil
+}
+
+// backfillPlatforms derives the platform of the questions added before platforms were recorded from their URL
+func backfillPlatforms(db *gorm.DB) error {
+	var questions []types.Question
+	if err := db.Where("platform = ? OR platform IS NULL", "").Find(&questions).Error; err != nil {
+		return err
+	}
+	for _, q := range questions {
+		if err := db.Model(&types.Question{}).Where("id = ?", q.ID).Update("platform", types.PlatformFromURL(q.URL)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
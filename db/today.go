@@ -2,30 +2,33 @@ package db
 
 import (
 	"dsacli/types"
-	"time"
 )
 
-func (d SQLDatabase) InsertTodayQuestions(questions []types.Question) error {
-	today := time.Now()
+// InsertTodayQuestions adds the questions to the plan of the given practice day along with
+// why each was picked, questions without a pick are stored without one
+func (d SQLDatabase) InsertTodayQuestions(date string, questions []types.Question, picks map[uint]types.Pick) error {
 	var todayQuestions []types.TodayQuestion
 
 	for _, q := range questions {
-		todayQuestions = append(todayQuestions, types.TodayQuestion{
-			Date:       today.Format("2006-01-02"),
+		entry := types.TodayQuestion{
+			Date:       date,
 			QuestionID: q.ID,
 			Completed:  false,
-		})
+		}
+		if pick, ok := picks[q.ID]; ok {
+			entry.Pick = &pick
+		}
+		todayQuestions = append(todayQuestions, entry)
 	}
 
 	res := d.db.Create(&todayQuestions)
 	return res.Error
 }
 
-func (d SQLDatabase) GetTodayQuestions() ([]types.Question, []types.TodayQuestion, error) {
+func (d SQLDatabase) GetTodayQuestions(date string) ([]types.Question, []types.TodayQuestion, error) {
 	var questions []types.TodayQuestion
-	today := time.Now().Format("2006-01-02")
 
-	res := d.db.Where("date = ?", today).Find(&questions)
+	res := d.db.Where("date = ?", date).Find(&questions)
 	if res.Error != nil {
 		return nil, nil, res.Error
 	}
@@ -44,12 +47,11 @@ func (d SQLDatabase) GetTodayQuestions() ([]types.Question, []types.TodayQuestio
 	return result, questions, nil
 }
 
-// GetTodayQuestionsWithStatus returns today's questions along with their completion status
-func (d SQLDatabase) GetTodayQuestionsWithStatus() ([]types.TodayQuestionWithStatus, error) {
+// GetTodayQuestionsWithStatus returns the plan of the given practice day along with the completion status
+func (d SQLDatabase) GetTodayQuestionsWithStatus(date string) ([]types.TodayQuestionWithStatus, error) {
 	var todayQuestions []types.TodayQuestion
-	today := time.Now().Format("2006-01-02")
 
-	res := d.db.Where("date = ?", today).Find(&todayQuestions)
+	res := d.db.Where("date = ?", date).Find(&todayQuestions)
 	if res.Error != nil {
 		return nil, res.Error
 	}
@@ -70,30 +72,48 @@ func (d SQLDatabase) GetTodayQuestionsWithStatus() ([]types.TodayQuestionWithSta
 	}
 
 	// Create a map for quick lookup of completion status
-	completionMap := make(map[uint]bool)
+	entries := make(map[uint]types.TodayQuestion)
 	for _, tq := range todayQuestions {
-		completionMap[tq.QuestionID] = tq.Completed
+		entries[tq.QuestionID] = tq
 	}
 
 	// Build the result with completion status
 	var result []types.TodayQuestionWithStatus
 	for _, q := range questions {
 		result = append(result, types.TodayQuestionWithStatus{
-			Question:  q,
-			Completed: completionMap[q.ID],
+			Question:    q,
+			Date:        date,
+			Completed:   entries[q.ID].Completed,
+			CarriedFrom: entries[q.ID].CarriedFrom,
+			Pick:        entries[q.ID].Pick,
 		})
 	}
 
 	return result, nil
 }
 
-// MarkTodayQuestionCompleted marks a specific question as completed for today
-func (d SQLDatabase) MarkTodayQuestionCompleted(questionID uint) error {
-	today := time.Now().Format("2006-01-02")
+// GetQuestionPlans returns the plan entries of a question, newest plan first
+func (d SQLDatabase) GetQuestionPlans(questionID uint) ([]types.TodayQuestion, error) {
+	var entries []types.TodayQuestion
+	res := d.db.Where("question_id = ?", questionID).Order("date DESC, id DESC").Find(&entries)
+	return entries, res.Error
+}
 
-	res := d.db.Model(&types.TodayQuestion{}).
-		Where("date = ? AND question_id = ?", today, questionID).
-		Update("completed", true)
+// MarkTodayQuestionCompleted marks the question as completed in the latest of the given plans
+// where it is still open, and returns the date of that plan ("" if it wasn't open in any)
+func (d SQLDatabase) MarkTodayQuestionCompleted(questionID uint, dates []string) (string, error) {
+	var open types.TodayQuestion
+	res := d.db.Where("question_id = ? AND completed = ? AND date IN ?", questionID, false, dates).
+		Order("date DESC").
+		Limit(1).
+		Find(&open)
+	if res.Error != nil || res.RowsAffected == 0 {
+		return "", res.Error
+	}
 
-	return res.Error
+	res = d.db.Model(&open).Update("completed", true)
+	if res.Error != nil {
+		return "", res.Error
+	}
+	return open.Date, nil
 }
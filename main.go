@@ -1,13 +1,29 @@
 package main
 
 import (
+	"dsacli/clock"
+	"dsacli/cmd/backlog"
 	"dsacli/cmd/complete"
+	"dsacli/cmd/goal"
+	"dsacli/cmd/graph"
+	"dsacli/cmd/leeches"
 	"dsacli/cmd/list"
+	"dsacli/cmd/mock"
+	"dsacli/cmd/pause"
+	"dsacli/cmd/question"
+	"dsacli/cmd/search"
 	"dsacli/cmd/seed"
+	"dsacli/cmd/serve"
+	"dsacli/cmd/settings"
+	"dsacli/cmd/show"
+	"dsacli/cmd/similar"
+	"dsacli/cmd/simulate"
 	"dsacli/cmd/status"
 	"dsacli/cmd/today"
+	"dsacli/cmd/tui"
 	"dsacli/config"
 	"dsacli/db"
+	"dsacli/practice"
 	"fmt"
 	"os"
 
@@ -17,12 +33,33 @@ import (
 
 const (
 	Version = "1.0.0"
+
+	// nowEnvVar overrides the current time like the hidden --now flag
+	nowEnvVar = "DSACLI_NOW"
 )
 
 func versionCmd(cmd *cobra.Command, args []string) {
 	fmt.Printf("dsacli version %s\n", Version)
 }
 
+// offlineCommands are the top-level commands that never read or write the practice database
+var offlineCommands = map[string]bool{
+	"completion": true,
+	"help":       true,
+	"config":     true,
+	"simulate":   true,
+	"version":    true,
+}
+
+// usesDatabase reports whether the command works on the practice database, so the
+// one-off migrations only run when they're needed
+func usesDatabase(cmd *cobra.Command) bool {
+	for cmd.HasParent() && cmd.Parent().HasParent() {
+		cmd = cmd.Parent()
+	}
+	return cmd.HasParent() && !offlineCommands[cmd.Name()]
+}
+
 func main() {
 	rootCmd := &cobra.Command{
 		Use:   "dsacli",
@@ -37,18 +74,85 @@ func main() {
 		Run:   versionCmd,
 	}
 
-	db, err := db.NewSQLDatabase(config.NewDefaultConfig())
+	cfg := config.NewDefaultConfig()
+	clk := clock.NewAdjustable()
+	cfg.Clock = clk
+
+	var err error
+	cfg.Settings, err = config.LoadSettings(cfg.SettingsPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading settings: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Hidden overrides used to test day boundaries and replay practice deterministically
+	var now string
+	var randSeed int64
+	var service *practice.Service
+	rootCmd.PersistentFlags().StringVar(&now, "now", "", "Pretend the current time is this (YYYY-MM-DD[THH:MM] or RFC3339), also read from $"+nowEnvVar)
+	rootCmd.PersistentFlags().Int64Var(&randSeed, "seed", 0, "Seed the random source so question picks are reproducible")
+	_ = rootCmd.PersistentFlags().MarkHidden("now")
+	_ = rootCmd.PersistentFlags().MarkHidden("seed")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if now == "" {
+			now = os.Getenv(nowEnvVar)
+		}
+		if now != "" {
+			t, err := clock.Parse(now)
+			if err != nil {
+				// main already reports the error; the usage text doesn't help here
+				cmd.SilenceUsage = true
+				cmd.SilenceErrors = true
+				return err
+			}
+			clk.Set(t)
+		}
+		if cmd.Flags().Changed("seed") {
+			service.Reseed(randSeed)
+		}
+		if !usesDatabase(cmd) {
+			return nil
+		}
+		// Migrations run after the overrides so they see the same clock as the command
+		migrated, err := service.Migrate()
+		if err != nil {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("migrating the database: %w", err)
+		}
+		if migrated.FixedAttempts > 0 {
+			fmt.Fprintf(os.Stderr, "Fixed %d attempt(s) with out of range feedback\n", migrated.FixedAttempts)
+		}
+		return nil
+	}
+
+	db, err := db.NewSQLDatabase(cfg)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing database: %v\n", err)
 		os.Exit(1)
 	}
 
-	rootCmd.AddCommand(today.GetCommand(db))
-	rootCmd.AddCommand(complete.GetCommand(db))
-	rootCmd.AddCommand(complete.GetProgressCommand(db))
+	service = practice.NewService(db, cfg)
+
+	rootCmd.AddCommand(today.GetCommand(service))
+	rootCmd.AddCommand(complete.GetCommand(service))
+	rootCmd.AddCommand(complete.GetProgressCommand(service))
+	rootCmd.AddCommand(backlog.GetCommand(service))
+	rootCmd.AddCommand(pause.GetCommand(service))
+	rootCmd.AddCommand(goal.GetCommand(service))
+	rootCmd.AddCommand(mock.GetCommand(service))
+	rootCmd.AddCommand(show.GetCommand(service))
+	rootCmd.AddCommand(leeches.GetCommand(service))
+	rootCmd.AddCommand(graph.GetCommand(service))
+	rootCmd.AddCommand(similar.GetCommand(service))
 	rootCmd.AddCommand(list.GetCommand(db))
 	rootCmd.AddCommand(seed.GetCommand(db))
-	rootCmd.AddCommand(status.GetCommand(db))
+	rootCmd.AddCommand(question.GetCommand(db))
+	rootCmd.AddCommand(search.GetCommand(db))
+	rootCmd.AddCommand(status.GetCommand(service))
+	rootCmd.AddCommand(tui.GetCommand(service))
+	rootCmd.AddCommand(serve.GetCommand(service, cfg))
+	rootCmd.AddCommand(settings.GetCommand(cfg))
+	rootCmd.AddCommand(simulate.GetCommand(db, cfg))
 	rootCmd.AddCommand(versionCommand)
 
 	if err := rootCmd.Execute(); err != nil {
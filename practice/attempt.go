@@ -0,0 +1,110 @@
+package practice
+
+import (
+	"dsacli/types"
+	"fmt"
+	"time"
+)
+
+// AttemptResult is the outcome of recording an attempt
+type AttemptResult struct {
+	Question types.Question `json:"question"`
+	Attempt  types.Attempt  `json:"attempt"`
+	// Leech is set when the attempt was the failed recall that made the question a leech
+	Leech *Leech `json:"leech,omitempty"`
+}
+
+// FailedRecall reports whether the attempt's p-score was too low to count as recalled
+func (r AttemptResult) FailedRecall() bool {
+	return r.Attempt.PScore < recallThreshold
+}
+
+// RecordAttempt applies the feedback to the question using spaced repetition, saves it,
+// marks the question as completed in the plan it was assigned in and records the attempt
+// in the history
+func (s *Service) RecordAttempt(questionID uint, feedback Feedback) (AttemptResult, error) {
+	return s.recordAttempt(questionID, feedback, nil)
+}
+
+// recordAttempt records an attempt, grouped into the mock session with the given ID if not nil
+func (s *Service) recordAttempt(questionID uint, feedback Feedback, sessionID *uint) (AttemptResult, error) {
+	feedback = feedback.Normalized()
+	if err := feedback.Validate(); err != nil {
+		return AttemptResult{}, err
+	}
+
+	question, err := s.Question(questionID)
+	if err != nil {
+		return AttemptResult{}, err
+	}
+
+	targets, err := s.TimeTargets()
+	if err != nil {
+		return AttemptResult{}, err
+	}
+
+	now := s.clock.Now()
+	applyFeedback(&question, feedback, targets.For(question.Difficulty), now)
+
+	// Make sure the next review comes before the final week of an interview goal
+	goal, hasGoal, err := s.ActiveGoal()
+	if err != nil {
+		return AttemptResult{}, err
+	}
+	if hasGoal {
+		if err := capInterval(&question, s.Date(), reviewDeadline(goal)); err != nil {
+			return AttemptResult{}, err
+		}
+	}
+
+	leech, err := s.leech(&question)
+	if err != nil {
+		return AttemptResult{}, err
+	}
+
+	if err := s.db.UpdateQuestion(question); err != nil {
+		return AttemptResult{}, fmt.Errorf("saving question: %w", err)
+	}
+
+	// Complete the question in the plan it was assigned in, which may be the previous
+	// day's when the session ran past the day boundary
+	planDate, err := s.db.MarkTodayQuestionCompleted(question.ID, []string{s.previousDate(), s.Date()})
+	if err != nil {
+		return AttemptResult{}, fmt.Errorf("marking today's question as completed: %w", err)
+	}
+	if planDate == "" {
+		planDate = s.Date()
+	}
+
+	attempt := types.Attempt{
+		QuestionID:  question.ID,
+		Date:        planDate,
+		CompletedAt: now,
+		TimeTaken:   feedback.TimeTaken,
+		HintsUsed:   feedback.HintsNeeded,
+		Optimality:  feedback.OptimalSolution,
+		Bugs:        feedback.AnyBugs,
+		PScore:      question.LastPScore,
+		SessionID:   sessionID,
+
+		Confidence:      feedback.Confidence,
+		SawSolution:     feedback.SawSolution,
+		FailureCategory: feedback.FailureCategory,
+		Approach:        feedback.Approach,
+	}
+	if err := s.db.InsertAttempt(attempt); err != nil {
+		return AttemptResult{}, fmt.Errorf("recording attempt: %w", err)
+	}
+
+	return AttemptResult{Question: question, Attempt: attempt, Leech: leech}, nil
+}
+
+// applyFeedback updates the question with the user's feedback using spaced repetition, scoring
+// the time taken against the given target
+func applyFeedback(question *types.Question, feedback Feedback, targetMinutes int, now time.Time) {
+	ProcessReview(question, feedback.TimeTaken, targetMinutes, feedback.HintsNeeded, feedback.OptimalSolution, feedback.AnyBugs, feedback.Confidence)
+
+	question.LastReviewed = &now
+	question.DueShift = 0
+	question.Attempted = true
+}
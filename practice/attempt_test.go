@@ -0,0 +1,36 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/types"
+	"testing"
+	"time"
+)
+
+func TestRecordAttemptOptionalFeedback(t *testing.T) {
+	service := newTestService(t, clock.Fixed(time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)), 1, 1)
+
+	feedback := Feedback{
+		TimeTaken:       25,
+		OptimalSolution: 5,
+		AnyBugs:         5,
+		Confidence:      1,
+		SawSolution:     true,
+		FailureCategory: types.FailurePattern,
+		Approach:        " sliding window ",
+	}
+	result, err := service.RecordAttempt(1, feedback)
+	if err != nil {
+		t.Fatalf("RecordAttempt() unexpected error: %v", err)
+	}
+
+	a := result.Attempt
+	if a.Confidence != 1 || !a.SawSolution || a.FailureCategory != types.FailurePattern || a.Approach != "sliding window" {
+		t.Errorf("RecordAttempt() attempt = %+v, want the optional feedback recorded", a)
+	}
+	// A perfect solve reported without any confidence scores lower than a confident one
+	if result.Question.LastPScore >= 0.95 || result.Question.Mastered {
+		t.Errorf("RecordAttempt() p-score = %.2f, mastered = %v, want low confidence to prevent instant mastery",
+			result.Question.LastPScore, result.Question.Mastered)
+	}
+}
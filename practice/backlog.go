@@ -0,0 +1,156 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/types"
+	"errors"
+	"fmt"
+)
+
+// ErrNotPaused is returned when resuming while practice isn't paused
+var ErrNotPaused = errors.New("practice is not paused")
+
+// Backlog returns the unfinished questions of earlier plans that weren't carried over
+// or dismissed, newest plan first
+func (s *Service) Backlog() ([]types.TodayQuestionWithStatus, error) {
+	backlog, err := s.db.GetBacklog(s.Date())
+	if err != nil {
+		return nil, fmt.Errorf("loading backlog: %w", err)
+	}
+	return backlog, nil
+}
+
+// ClearBacklog dismisses the backlog, or only the plan of the given date when it isn't
+// empty, and returns how many questions were dismissed
+func (s *Service) ClearBacklog(date string) (int, error) {
+	if date != "" {
+		if _, err := clock.ParseDate(date); err != nil {
+			return 0, err
+		}
+	}
+	cleared, err := s.db.DismissBacklog(s.Date(), date)
+	if err != nil {
+		return 0, fmt.Errorf("clearing backlog: %w", err)
+	}
+	return cleared, nil
+}
+
+// carryOver rolls up to the configured cap of unfinished questions from missed plans into
+// the plan of the given date, most recent first. Questions already in ignore or that can't be
+// scheduled anymore are skipped, and so are the ones that aren't weak when weakOnly is set.
+func (s *Service) carryOver(date string, ignore []uint, weakOnly bool) ([]types.TodayQuestionWithStatus, error) {
+	if s.settings.CarryOverCap == 0 {
+		return nil, nil
+	}
+
+	backlog, err := s.db.GetBacklog(date)
+	if err != nil {
+		return nil, fmt.Errorf("loading backlog: %w", err)
+	}
+
+	skip := make(map[uint]bool)
+	for _, id := range ignore {
+		skip[id] = true
+	}
+
+	var carried []types.TodayQuestionWithStatus
+	for _, missed := range backlog {
+		if len(carried) == s.settings.CarryOverCap {
+			break
+		}
+		if skip[missed.Question.ID] || !s.schedulable(missed.Question) || s.reviewedSince(missed.Question, missed.Date) {
+			continue
+		}
+		if weakOnly && (!missed.Question.Attempted || missed.Question.Mastered) {
+			continue
+		}
+		skip[missed.Question.ID] = true
+		carried = append(carried, missed)
+	}
+
+	if err := s.db.CarryOverTodayQuestions(date, carried); err != nil {
+		return nil, fmt.Errorf("carrying over missed questions: %w", err)
+	}
+
+	for i := range carried {
+		carried[i].CarriedFrom = carried[i].Date
+		carried[i].Date = date
+	}
+	return carried, nil
+}
+
+// reviewedSince reports whether the question was attempted on or after the given practice day,
+// e.g. when it was picked again in a later plan
+func (s *Service) reviewedSince(question types.Question, date string) bool {
+	return question.LastReviewed != nil && s.calendar.Date(*question.LastReviewed) >= date
+}
+
+// ActivePause returns the pause covering today, if any
+func (s *Service) ActivePause() (types.Pause, bool, error) {
+	pause, ok, err := s.db.GetPause()
+	if err != nil {
+		return types.Pause{}, false, fmt.Errorf("loading pause: %w", err)
+	}
+	if !ok || pause.Until <= s.Date() {
+		return types.Pause{}, false, nil
+	}
+	return pause, true, nil
+}
+
+// Pause stops planning questions until the given date and pushes every due date back by
+// the length of the pause. Pausing again while paused moves the end of the pause.
+func (s *Service) Pause(until string) (types.Pause, error) {
+	if _, err := clock.ParseDate(until); err != nil {
+		return types.Pause{}, err
+	}
+	today := s.Date()
+	if until <= today {
+		return types.Pause{}, fmt.Errorf("pause must end after today (%s)", today)
+	}
+
+	pause := types.Pause{StartDate: today, Until: until}
+	shiftFrom := today
+	if current, ok, err := s.ActivePause(); err != nil {
+		return types.Pause{}, err
+	} else if ok {
+		// Due dates were already shifted up to the current end of the pause
+		pause.StartDate = current.StartDate
+		shiftFrom = current.Until
+	}
+
+	days, err := clock.DaysBetween(shiftFrom, until)
+	if err != nil {
+		return types.Pause{}, err
+	}
+	if err := s.db.ShiftDueDates(days); err != nil {
+		return types.Pause{}, fmt.Errorf("shifting due dates: %w", err)
+	}
+	if err := s.db.SetPause(pause); err != nil {
+		return types.Pause{}, fmt.Errorf("saving pause: %w", err)
+	}
+	return pause, nil
+}
+
+// Resume ends the current pause early, pulling due dates forward by the days that were
+// left so they aren't pushed back further than the time actually spent away
+func (s *Service) Resume() (types.Pause, error) {
+	pause, ok, err := s.ActivePause()
+	if err != nil {
+		return types.Pause{}, err
+	}
+	if !ok {
+		return types.Pause{}, ErrNotPaused
+	}
+
+	remaining, err := clock.DaysBetween(s.Date(), pause.Until)
+	if err != nil {
+		return types.Pause{}, err
+	}
+	if err := s.db.ShiftDueDates(-remaining); err != nil {
+		return types.Pause{}, fmt.Errorf("shifting due dates: %w", err)
+	}
+	if err := s.db.ClearPause(); err != nil {
+		return types.Pause{}, fmt.Errorf("clearing pause: %w", err)
+	}
+	return pause, nil
+}
@@ -0,0 +1,136 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/config"
+	"dsacli/types"
+	"testing"
+	"time"
+)
+
+func TestCarryOver(t *testing.T) {
+	clk := clock.NewAdjustable()
+	clk.Set(time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC))
+	settings := config.Settings{Timezone: "UTC", CarryOverCap: 1}
+	service := newTestServiceWithSettings(t, clk, 1, 10, settings)
+
+	missed, err := service.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+
+	// Skip a day
+	clk.Advance(48 * time.Hour)
+	backlog, err := service.Backlog()
+	if err != nil {
+		t.Fatalf("Backlog() unexpected error: %v", err)
+	}
+	if len(backlog) != 2 {
+		t.Fatalf("Expected the 2 missed questions in the backlog, got %d", len(backlog))
+	}
+
+	plan, err := service.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	if plan.Carried != 1 {
+		t.Errorf("Expected 1 carried question (the cap), got %d", plan.Carried)
+	}
+	if len(plan.Questions) != 3 {
+		t.Errorf("Expected 1 carried and 2 new questions, got %d", len(plan.Questions))
+	}
+	if carried := plan.Questions[0]; carried.CarriedFrom != "2024-03-10" || !questionOneOf(carried.Question, missed.Questions[0].Question, missed.Questions[1].Question) {
+		t.Errorf("Expected a question carried from 2024-03-10, got %+v", carried)
+	}
+
+	backlog, err = service.Backlog()
+	if err != nil {
+		t.Fatalf("Backlog() unexpected error: %v", err)
+	}
+	if len(backlog) != 1 {
+		t.Fatalf("Expected 1 question left in the backlog, got %d", len(backlog))
+	}
+
+	cleared, err := service.ClearBacklog("")
+	if err != nil {
+		t.Fatalf("ClearBacklog() unexpected error: %v", err)
+	}
+	if cleared != 1 {
+		t.Errorf("Expected 1 cleared question, got %d", cleared)
+	}
+	if backlog, _ := service.Backlog(); len(backlog) != 0 {
+		t.Errorf("Expected an empty backlog after clearing, got %d", len(backlog))
+	}
+}
+
+func TestPauseShiftsDueDates(t *testing.T) {
+	clk := clock.NewAdjustable()
+	clk.Set(time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC))
+	service := newTestServiceWithSettings(t, clk, 1, 4, config.Settings{Timezone: "UTC"})
+
+	if _, err := service.RecordAttempt(1, Feedback{TimeTaken: 10, OptimalSolution: 5, AnyBugs: 5}); err != nil {
+		t.Fatalf("RecordAttempt() unexpected error: %v", err)
+	}
+	before, err := service.Question(1)
+	if err != nil {
+		t.Fatalf("Question() unexpected error: %v", err)
+	}
+
+	if _, err := service.Pause("2024-03-09"); err == nil {
+		t.Errorf("Expected an error pausing until a past date")
+	}
+	if _, err := service.Pause("2024-03-20"); err != nil {
+		t.Fatalf("Pause() unexpected error: %v", err)
+	}
+
+	after, _ := service.Question(1)
+	if shift := dueShift(t, before, after); shift != 10*24*time.Hour {
+		t.Errorf("Expected due date to move 10 days, moved %v", shift)
+	}
+	if !after.LastReviewed.Equal(*before.LastReviewed) {
+		t.Errorf("Expected the last review to be kept at %v, got %v", before.LastReviewed, after.LastReviewed)
+	}
+	if details, _ := service.Details(1); details.Due != "2024-03-21" {
+		t.Errorf("Expected the question to be due on 2024-03-21, got %s", details.Due)
+	}
+
+	plan, err := service.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	if plan.PausedUntil != "2024-03-20" || len(plan.Questions) != 0 {
+		t.Errorf("Expected no questions while paused, got %+v", plan)
+	}
+
+	// Come back 4 days early
+	clk.Advance(6 * 24 * time.Hour)
+	if _, err := service.Resume(); err != nil {
+		t.Fatalf("Resume() unexpected error: %v", err)
+	}
+	resumed, _ := service.Question(1)
+	if shift := dueShift(t, before, resumed); shift != 6*24*time.Hour {
+		t.Errorf("Expected due date to stay moved by the 6 days away, moved %v", shift)
+	}
+	if _, err := service.Resume(); err != ErrNotPaused {
+		t.Errorf("Expected ErrNotPaused resuming twice, got %v", err)
+	}
+
+	plan, err = service.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	if plan.PausedUntil != "" || len(plan.Questions) == 0 {
+		t.Errorf("Expected questions to be planned after resuming, got %+v", plan)
+	}
+}
+
+// dueShift returns how far the due date of a question moved between two of its states
+func dueShift(t *testing.T, before, after types.Question) time.Duration {
+	t.Helper()
+	from, ok := before.DueDate()
+	to, ok2 := after.DueDate()
+	if !ok || !ok2 {
+		t.Fatalf("Expected the question to have a due date")
+	}
+	return to.Sub(from)
+}
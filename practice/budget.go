@@ -0,0 +1,209 @@
+package practice
+
+import (
+	"dsacli/config"
+	"dsacli/types"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Budget returns the practice time in minutes of the current practice day, 0 meaning
+// a fixed number of questions is planned instead
+func (s *Service) Budget() int {
+	return s.settings.Budget(s.calendar.Day(s.Now()))
+}
+
+// expectedMinutes returns the median time taken over the solved attempts of a question,
+// falling back to the time target of its difficulty
+func expectedMinutes(q types.Question, times map[uint][]int, targets config.TimeTargets) int {
+	solved := times[q.ID]
+	if len(solved) == 0 {
+		return targets.For(q.Difficulty)
+	}
+	return median(solved)
+}
+
+// median returns the middle value of a non-empty list, rounding up between two middle values
+func median(values []int) int {
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle] + 1) / 2
+	}
+	return sorted[middle]
+}
+
+// solveTimes groups the time taken of every solved attempt by question
+func (s *Service) solveTimes() (map[uint][]int, error) {
+	attempts, err := s.db.GetAttempts()
+	if err != nil {
+		return nil, fmt.Errorf("loading attempts: %w", err)
+	}
+	times := make(map[uint][]int)
+	for _, a := range attempts {
+		if a.TimeTaken > 0 {
+			times[a.QuestionID] = append(times[a.QuestionID], a.TimeTaken)
+		}
+	}
+	return times, nil
+}
+
+// plannedMinutes returns the expected time to solve the carried and the generated questions
+func (s *Service) plannedMinutes(carried []types.TodayQuestionWithStatus, questions []types.Question) (int, error) {
+	times, err := s.solveTimes()
+	if err != nil {
+		return 0, err
+	}
+	planned := 0
+	for _, q := range carried {
+		planned += expectedMinutes(q.Question, times, s.settings.TimeTargets)
+	}
+	for _, q := range questions {
+		planned += expectedMinutes(q, times, s.settings.TimeTargets)
+	}
+	return planned, nil
+}
+
+// budgetPlan fills the budget with reviews and new questions of the phase the progression
+// gates are in. The new questions get the newRatio share of it and the reviews the rest,
+// whatever one side leaves unused goes to the other. At least one question is planned while
+// any is left. Why each question was picked is recorded in the trace.
+func (s *Service) budgetPlan(rng *rand.Rand, budget int, carried []types.TodayQuestionWithStatus, ignore []uint, trace *Trace) ([]types.Question, string, int, error) {
+	times, err := s.solveTimes()
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	planned := 0
+	for _, q := range carried {
+		planned += expectedMinutes(q.Question, times, s.settings.TimeTargets)
+	}
+
+	questions, err := s.db.GetActiveQuestions()
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("failed to load questions: %w", err)
+	}
+	byTier := make(map[string][]types.Question)
+	for _, q := range questions {
+		byTier[q.Difficulty] = append(byTier[q.Difficulty], q)
+	}
+	phase := gatedPhase(byTier, s.settings.Gates)
+	questions = filterOutQuestions(questions, ignore)
+
+	tiers := make(map[string]bool)
+	for _, difficulty := range types.Difficulties {
+		tiers[difficulty] = true
+		if difficulty == phase {
+			break
+		}
+	}
+	fresh := companiesFirst(s.freshPool(rng, questions, tiers, nextTier(phase)), s.settings.Focus())
+
+	reviews, failures, err := s.reviewPool(questions, tiers)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	freshCount, ranked := len(fresh), reviews
+
+	remaining := budget - planned
+	newBudget := int(float64(remaining) * s.settings.NewRatio)
+	var picked []types.Question
+	fill := func(pool []types.Question, minutes int) ([]types.Question, int) {
+		var rest []types.Question
+		for _, q := range pool {
+			expected := expectedMinutes(q, times, s.settings.TimeTargets)
+			if expected <= minutes {
+				picked = append(picked, q)
+				minutes -= expected
+				planned += expected
+				continue
+			}
+			rest = append(rest, q)
+		}
+		return rest, minutes
+	}
+
+	fresh, left := fill(fresh, newBudget)
+	reviews, left = fill(reviews, remaining-newBudget+left)
+	fresh, _ = fill(fresh, left)
+
+	forced := false
+	if len(picked) == 0 && len(carried) == 0 {
+		if len(fresh) == 0 {
+			fresh = reviews
+		}
+		if len(fresh) > 0 {
+			picked = append(picked, fresh[0])
+			planned += expectedMinutes(fresh[0], times, s.settings.TimeTargets)
+			forced = true
+		}
+	}
+
+	var pickedReviews []types.Question
+	for _, q := range picked {
+		if q.Attempted {
+			pickedReviews = append(pickedReviews, q)
+		} else {
+			trace.pickRandom(q, freshCount)
+		}
+	}
+	trace.pickRanked(pickedReviews, ranked, failures, s.clock.Now())
+	trace.leaks(picked, phase)
+	for _, q := range picked {
+		trace.reason(q.ID, fmt.Sprintf("~%d min expected", expectedMinutes(q, times, s.settings.TimeTargets)))
+		if forced {
+			trace.reason(q.ID, "nothing fits the budget, planned anyway")
+		}
+	}
+	return picked, phase, planned, nil
+}
+
+// freshPool returns the unattempted questions of the given tiers in random order, with the
+// next tier's blended in at the gate leak share
+func (s *Service) freshPool(rng *rand.Rand, questions []types.Question, tiers map[string]bool, next string) []types.Question {
+	var current, leaked []types.Question
+	for _, q := range questions {
+		switch {
+		case q.Attempted:
+		case tiers[q.Difficulty]:
+			current = append(current, q)
+		case q.Difficulty == next:
+			leaked = append(leaked, q)
+		}
+	}
+	rng.Shuffle(len(current), func(i, j int) { current[i], current[j] = current[j], current[i] })
+	if s.settings.GateLeak == 0 || len(leaked) == 0 {
+		return current
+	}
+
+	rng.Shuffle(len(leaked), func(i, j int) { leaked[i], leaked[j] = leaked[j], leaked[i] })
+	pool := make([]types.Question, 0, len(current))
+	for len(current) > 0 {
+		if len(leaked) > 0 && rng.Float64() < s.settings.GateLeak {
+			pool, leaked = append(pool, leaked[0]), leaked[1:]
+			continue
+		}
+		pool, current = append(pool, current[0]), current[1:]
+	}
+	return pool
+}
+
+// reviewPool returns the attempted questions of the given tiers worth reviewing, highest
+// priority first, see rankReviews, along with the failure streaks they were ranked by
+func (s *Service) reviewPool(questions []types.Question, tiers map[string]bool) ([]types.Question, map[uint]int, error) {
+	attempts, err := s.db.GetAttempts()
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading attempts: %w", err)
+	}
+
+	var pool []types.Question
+	for _, q := range questions {
+		if tiers[q.Difficulty] {
+			pool = append(pool, q)
+		}
+	}
+	failures := failureStreaks(attempts)
+	return rankReviews(pool, failures, s.clock.Now()), failures, nil
+}
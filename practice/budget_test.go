@@ -0,0 +1,106 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/config"
+	"dsacli/types"
+	"testing"
+	"time"
+)
+
+func TestExpectedMinutes(t *testing.T) {
+	times := map[uint][]int{1: {30}, 2: {40, 10, 25}, 3: {10, 21}}
+
+	tests := []struct {
+		name     string
+		question types.Question
+		expected int
+	}{
+		{name: "Single attempt", question: types.Question{ID: 1, Difficulty: types.DifficultyEasy}, expected: 30},
+		{name: "Median of odd count", question: types.Question{ID: 2, Difficulty: types.DifficultyEasy}, expected: 25},
+		{name: "Median of even count", question: types.Question{ID: 3, Difficulty: types.DifficultyEasy}, expected: 16},
+		{name: "Easy default", question: types.Question{ID: 4, Difficulty: types.DifficultyEasy}, expected: 20},
+		{name: "Hard default", question: types.Question{ID: 4, Difficulty: types.DifficultyHard}, expected: 45},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expectedMinutes(tt.question, times, config.DefaultTimeTargets()); got != tt.expected {
+				t.Errorf("expectedMinutes() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPlanTodayBudget(t *testing.T) {
+	friday := time.Date(2024, 3, 8, 12, 0, 0, 0, time.Local)
+	settings := config.DefaultSettings()
+	settings.BudgetMinutes = 60
+	settings.WeekendBudgetMinutes = 100
+
+	tests := []struct {
+		name          string
+		now           time.Time
+		expectedCount int
+		expectedTotal int
+	}{
+		{name: "Weekday budget", now: friday, expectedCount: 3, expectedTotal: 60},
+		{name: "Weekend budget", now: friday.AddDate(0, 0, 1), expectedCount: 5, expectedTotal: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestServiceWithSettings(t, clock.Fixed(tt.now), 1, 10, settings)
+
+			plan, err := service.PlanToday(false)
+			if err != nil {
+				t.Fatalf("PlanToday() unexpected error: %v", err)
+			}
+			if len(plan.Questions) != tt.expectedCount {
+				t.Errorf("Expected %d questions, got %d", tt.expectedCount, len(plan.Questions))
+			}
+			if plan.PlannedMinutes != tt.expectedTotal {
+				t.Errorf("Expected %d planned minutes, got %d", tt.expectedTotal, plan.PlannedMinutes)
+			}
+		})
+	}
+}
+
+func TestPlanTodayBudgetMix(t *testing.T) {
+	clk := clock.NewAdjustable()
+	clk.Set(time.Date(2024, 3, 4, 12, 0, 0, 0, time.Local))
+	settings := config.DefaultSettings()
+	settings.BudgetMinutes = 80
+	settings.NewRatio = 0.5
+	service := newTestServiceWithSettings(t, clk, 1, 10, settings)
+
+	// Solve a few questions quickly so they become cheap reviews
+	for id := uint(1); id <= 3; id++ {
+		feedback := Feedback{HintsNeeded: MaxRating, TimeTaken: 10, OptimalSolution: MaxRating, AnyBugs: MaxRating}
+		if _, err := service.RecordAttempt(id, feedback); err != nil {
+			t.Fatalf("RecordAttempt() unexpected error: %v", err)
+		}
+	}
+	clk.Advance(30 * 24 * time.Hour)
+
+	plan, err := service.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+
+	fresh, reviews := 0, 0
+	for _, q := range plan.Questions {
+		if q.Question.Attempted {
+			reviews++
+		} else {
+			fresh++
+		}
+	}
+	// 40 minutes of new easy questions (20 each) and 30 minutes of reviews (10 each)
+	if fresh != 2 || reviews != 3 {
+		t.Errorf("Expected 2 new questions and 3 reviews, got %d and %d", fresh, reviews)
+	}
+	if plan.PlannedMinutes > plan.BudgetMinutes {
+		t.Errorf("Planned %d minutes over the %d minute budget", plan.PlannedMinutes, plan.BudgetMinutes)
+	}
+}
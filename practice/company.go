@@ -0,0 +1,115 @@
+package practice
+
+import (
+	"dsacli/types"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Coverage is how much of the questions asked by a company, or of a curated list, was practiced
+type Coverage struct {
+	Name      string `json:"name"`
+	Total     int    `json:"total"`
+	Attempted int    `json:"attempted"`
+	Mastered  int    `json:"mastered"`
+}
+
+// FocusOn replaces the companies new questions are biased towards for the rest of the run,
+// e.g. for "dsacli today --company amazon"
+func (s *Service) FocusOn(companies []string) {
+	s.settings.FocusCompanies = strings.Join(companies, ",")
+}
+
+// askedBy returns the companies of the list that ask the question
+func askedBy(q types.Question, companies []string) []string {
+	var asked []string
+	for _, c := range companies {
+		if q.AskedBy(c) {
+			asked = append(asked, c)
+		}
+	}
+	return asked
+}
+
+// preferCompanies narrows the unattempted questions of a pool down to the ones asked by the
+// companies, keeping the attempted ones. The pool is returned as is when fewer than needed
+// unattempted questions are asked by them, so focusing never shortens a plan.
+func preferCompanies(pool []types.Question, companies []string, needed int) []types.Question {
+	if len(companies) == 0 {
+		return pool
+	}
+	var preferred []types.Question
+	asked := 0
+	for _, q := range pool {
+		if !q.Attempted && len(askedBy(q, companies)) == 0 {
+			continue
+		}
+		if !q.Attempted {
+			asked++
+		}
+		preferred = append(preferred, q)
+	}
+	if asked < needed {
+		return pool
+	}
+	return preferred
+}
+
+// companiesFirst moves the questions asked by the companies to the front of the pool,
+// keeping the order otherwise
+func companiesFirst(pool []types.Question, companies []string) []types.Question {
+	if len(companies) == 0 {
+		return pool
+	}
+	sorted := append([]types.Question(nil), pool...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(askedBy(sorted[i], companies)) > 0 && len(askedBy(sorted[j], companies)) == 0
+	})
+	return sorted
+}
+
+// buildCoverage counts the questions of every label returned by labels, such as the companies
+// or the lists of a question, ordered by name. Labels differing only in case are counted together.
+func buildCoverage(questions []types.Question, labels func(types.Question) []string) []Coverage {
+	byLabel := make(map[string]*Coverage)
+	for _, q := range questions {
+		for _, label := range labels(q) {
+			key := strings.ToLower(label)
+			c, ok := byLabel[key]
+			if !ok {
+				c = &Coverage{Name: label}
+				byLabel[key] = c
+			}
+			c.Total++
+			if q.Attempted {
+				c.Attempted++
+			}
+			if q.Mastered {
+				c.Mastered++
+			}
+		}
+	}
+
+	coverage := make([]Coverage, 0, len(byLabel))
+	for _, c := range byLabel {
+		coverage = append(coverage, *c)
+	}
+	sort.Slice(coverage, func(i, j int) bool {
+		return strings.ToLower(coverage[i].Name) < strings.ToLower(coverage[j].Name)
+	})
+	return coverage
+}
+
+// traceCompanies records the focus companies and which of them ask each new question picked
+func (t *Trace) traceCompanies(questions []types.Question, companies []string) {
+	if t == nil || len(companies) == 0 {
+		return
+	}
+	t.Companies = companies
+	for _, q := range questions {
+		if asked := askedBy(q, companies); !q.Attempted && len(asked) > 0 {
+			t.reason(q.ID, fmt.Sprintf("asked by %s", strings.Join(asked, ", ")))
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/types"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func questionIDs(questions []types.Question) []uint {
+	ids := []uint{}
+	for _, q := range questions {
+		ids = append(ids, q.ID)
+	}
+	return ids
+}
+
+func TestPreferCompanies(t *testing.T) {
+	pool := []types.Question{
+		{ID: 1},
+		{ID: 2, Companies: []string{"Amazon"}},
+		{ID: 3, Attempted: true},
+		{ID: 4, Companies: []string{"google"}},
+	}
+
+	tests := []struct {
+		name      string
+		companies []string
+		needed    int
+		want      []uint
+	}{
+		{name: "No focus", needed: 1, want: []uint{1, 2, 3, 4}},
+		{name: "Unattempted narrowed to the company", companies: []string{"amazon"}, needed: 1, want: []uint{2, 3}},
+		{name: "Any of the companies", companies: []string{"amazon", "google"}, needed: 2, want: []uint{2, 3, 4}},
+		{name: "Not enough asked questions", companies: []string{"amazon"}, needed: 2, want: []uint{1, 2, 3, 4}},
+		{name: "Company asking nothing", companies: []string{"meta"}, needed: 1, want: []uint{1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := questionIDs(preferCompanies(pool, tt.companies, tt.needed)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("preferCompanies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompaniesFirst(t *testing.T) {
+	pool := []types.Question{
+		{ID: 1},
+		{ID: 2, Companies: []string{"amazon"}},
+		{ID: 3},
+		{ID: 4, Companies: []string{"google", "amazon"}},
+	}
+	if got := questionIDs(companiesFirst(pool, []string{"amazon"})); !reflect.DeepEqual(got, []uint{2, 4, 1, 3}) {
+		t.Errorf("companiesFirst() = %v, want [2 4 1 3]", got)
+	}
+	if got := questionIDs(pool); !reflect.DeepEqual(got, []uint{1, 2, 3, 4}) {
+		t.Errorf("companiesFirst() reordered its input to %v", got)
+	}
+}
+
+func TestBuildCoverage(t *testing.T) {
+	questions := []types.Question{
+		{ID: 1, Companies: []string{"Amazon", "google"}, Attempted: true, Mastered: true},
+		{ID: 2, Companies: []string{"amazon"}, Attempted: true},
+		{ID: 3, Companies: []string{"Google"}},
+		{ID: 4},
+	}
+	want := []Coverage{
+		{Name: "Amazon", Total: 2, Attempted: 2, Mastered: 1},
+		{Name: "google", Total: 2, Attempted: 1, Mastered: 1},
+	}
+	got := buildCoverage(questions, func(q types.Question) []string { return q.Companies })
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildCoverage() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPlanTodayFocusesOnCompanies(t *testing.T) {
+	service := newTestService(t, clock.Fixed(time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)), 1, 6)
+	for _, id := range []uint{4, 5} {
+		q, err := service.Question(id)
+		if err != nil {
+			t.Fatalf("Question(%d) unexpected error: %v", id, err)
+		}
+		q.Companies = []string{"amazon"}
+		if err := service.db.UpdateQuestion(q); err != nil {
+			t.Fatalf("UpdateQuestion() unexpected error: %v", err)
+		}
+	}
+
+	service.FocusOn([]string{"amazon"})
+	plan, err := service.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	if ids := planIDs(plan); len(ids) != 2 || !reflect.DeepEqual(map[uint]bool{ids[0]: true, ids[1]: true}, map[uint]bool{4: true, 5: true}) {
+		t.Fatalf("PlanToday() = %v, want the questions asked by amazon", ids)
+	}
+	if reasons := plan.Questions[0].Pick.Reasons; reasons[len(reasons)-1] != "asked by amazon" {
+		t.Errorf("PlanToday() reasons = %v, want the company recorded", reasons)
+	}
+}
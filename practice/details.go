@@ -0,0 +1,57 @@
+package practice
+
+import (
+	"dsacli/types"
+	"errors"
+	"fmt"
+)
+
+// QuestionDetails is a question along with its review schedule and planning history
+type QuestionDetails struct {
+	Question types.Question `json:"question"`
+	// Due is the practice day the question is due for review, empty if it was never reviewed
+	Due string `json:"due,omitempty"`
+	// Plans are the plan entries of the question, newest first
+	Plans []types.TodayQuestion `json:"plans"`
+	// Prerequisites are the questions to attempt before this one, Dependents the ones waiting on it
+	Prerequisites []types.Question `json:"prerequisites"`
+	Dependents    []types.Question `json:"dependents"`
+	// HasStatement is true when the problem statement is stored for practicing offline
+	HasStatement bool `json:"has_statement"`
+}
+
+// LastPick returns the latest plan entry that recorded why the question was picked
+func (d QuestionDetails) LastPick() (types.TodayQuestion, bool) {
+	for _, entry := range d.Plans {
+		if entry.Pick != nil {
+			return entry, true
+		}
+	}
+	return types.TodayQuestion{}, false
+}
+
+// Details returns the question with the given ID along with its planning history
+func (s *Service) Details(id uint) (QuestionDetails, error) {
+	question, err := s.Question(id)
+	if err != nil {
+		return QuestionDetails{}, err
+	}
+	plans, err := s.db.GetQuestionPlans(id)
+	if err != nil {
+		return QuestionDetails{}, fmt.Errorf("loading plans of question %d: %w", id, err)
+	}
+
+	details := QuestionDetails{Question: question, Plans: plans}
+	if due, ok := question.DueDate(); ok {
+		details.Due = s.calendar.Date(due)
+	}
+	if details.Prerequisites, details.Dependents, err = s.neighbours(id); err != nil {
+		return QuestionDetails{}, err
+	}
+	if _, err := s.Statement(id); err == nil {
+		details.HasStatement = true
+	} else if !errors.Is(err, ErrNoStatement) {
+		return QuestionDetails{}, err
+	}
+	return details, nil
+}
@@ -0,0 +1,212 @@
+package practice
+
+import (
+	"dsacli/types"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Ranges and units of the feedback fields. Every way of giving feedback (the complete prompts
+// and flags, the TUI, mock interviews and the API) goes through Feedback.Validate.
+const (
+	// Ratings go from MinRating (worst) to MaxRating (best)
+	MinRating = 1
+	MaxRating = 5
+
+	// Hints are counted, from none up to MaxHints
+	MaxHints = 10
+
+	// Time is in minutes, from 1 to MaxMinutes, or UnsolvedTimeValue when the question wasn't solved
+	MaxMinutes        = 300
+	UnsolvedTimeValue = -1
+
+	// NoConfidence is the confidence of feedback that didn't report one
+	NoConfidence = 0
+)
+
+// Feedback is what the user reports after attempting a question
+type Feedback struct {
+	HintsNeeded     int `json:"hints_needed"`     // number of hints used
+	TimeTaken       int `json:"time_taken"`       // minutes, UnsolvedTimeValue if unsolved
+	OptimalSolution int `json:"optimal_solution"` // rating, 1=not optimal, 5=very optimal
+	AnyBugs         int `json:"any_bugs"`         // rating, 1=many bugs, 5=no bugs
+	// Optional, see types.Attempt
+	Confidence      int    `json:"confidence,omitempty"`
+	SawSolution     bool   `json:"saw_solution,omitempty"`
+	FailureCategory string `json:"failure_category,omitempty"`
+	Approach        string `json:"approach,omitempty"`
+}
+
+// Normalized returns the feedback with its free-form fields cleaned up
+func (f Feedback) Normalized() Feedback {
+	f.FailureCategory = strings.ToLower(strings.TrimSpace(f.FailureCategory))
+	f.Approach = strings.TrimSpace(f.Approach)
+	return f
+}
+
+// Validate checks that every field of the normalized feedback is within its allowed range
+func (f Feedback) Validate() error {
+	f = f.Normalized()
+	checks := []error{
+		ValidateHints(f.HintsNeeded),
+		ValidateTimeTaken(f.TimeTaken),
+		ValidateRating("optimal_solution", f.OptimalSolution),
+		ValidateRating("any_bugs", f.AnyBugs),
+		ValidateConfidence(f.Confidence),
+		ValidateFailureCategory(f.FailureCategory),
+	}
+	for _, err := range checks {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateHints checks a number of hints
+func ValidateHints(hints int) error {
+	if hints < 0 || hints > MaxHints {
+		return fmt.Errorf("hints_needed must be a number of hints between 0 and %d", MaxHints)
+	}
+	return nil
+}
+
+// ValidateTimeTaken checks a time taken in minutes
+func ValidateTimeTaken(minutes int) error {
+	if minutes != UnsolvedTimeValue && (minutes < 1 || minutes > MaxMinutes) {
+		return fmt.Errorf("time_taken must be between 1 and %d minutes, or %d if unsolved", MaxMinutes, UnsolvedTimeValue)
+	}
+	return nil
+}
+
+// ValidateRating checks a rating of the named field
+func ValidateRating(field string, rating int) error {
+	if rating < MinRating || rating > MaxRating {
+		return fmt.Errorf("%s must be between %d and %d", field, MinRating, MaxRating)
+	}
+	return nil
+}
+
+// ValidateConfidence checks an optional confidence rating
+func ValidateConfidence(confidence int) error {
+	if confidence != NoConfidence {
+		return ValidateRating("confidence", confidence)
+	}
+	return nil
+}
+
+// ValidateFailureCategory checks an optional failure category
+func ValidateFailureCategory(category string) error {
+	if category != "" && !types.IsValidFailureCategory(category) {
+		return fmt.Errorf("failure_category must be one of %s", strings.Join(types.FailureCategories, ", "))
+	}
+	return nil
+}
+
+// hintsRating converts the number of hints to a rating, 5 for none down to 1 for 4 or more
+func hintsRating(hints int) int {
+	return max(MaxRating-hints, MinRating)
+}
+
+// NormalizeHistory brings the stored attempts in line with the feedback schema. Feedback used to
+// be checked differently by every input, e.g. a hint count of -1 was accepted and made the
+// p-score infinite. Out of range values are clamped, the p-scores of the clamped attempts
+// recomputed and the spaced repetition state of the questions whose scores changed is rebuilt from their attempts. It
+// returns the number of attempts fixed, running it again changes nothing.
+func (s *Service) NormalizeHistory() (int, error) {
+	attempts, err := s.db.GetAttempts()
+	if err != nil {
+		return 0, fmt.Errorf("loading attempts: %w", err)
+	}
+	all, err := s.db.GetAllQuestions()
+	if err != nil {
+		return 0, fmt.Errorf("loading questions: %w", err)
+	}
+	questions := make(map[uint]types.Question, len(all))
+	for _, q := range all {
+		questions[q.ID] = q
+	}
+	targets := s.timeTargets(attempts, all)
+
+	byQuestion := make(map[uint][]types.Attempt)
+	changed := make(map[uint]bool)
+	fixed := 0
+	for _, a := range attempts {
+		if normalizeAttempt(&a, targets.For(questions[a.QuestionID].Difficulty)) {
+			if err := s.db.UpdateAttempt(a); err != nil {
+				return fixed, fmt.Errorf("saving attempt %d: %w", a.ID, err)
+			}
+			changed[a.QuestionID] = true
+			fixed++
+		}
+		byQuestion[a.QuestionID] = append(byQuestion[a.QuestionID], a)
+	}
+
+	for id := range changed {
+		question, ok := questions[id]
+		if !ok {
+			continue
+		}
+		history := byQuestion[id]
+		if len(history) == question.AttemptCount {
+			question = replay(question, history, targets.For(question.Difficulty))
+		} else {
+			// Attempts made before the history was recorded can't be replayed
+			question.LastPScore = history[len(history)-1].PScore
+		}
+		if err := s.db.UpdateQuestion(question); err != nil {
+			return fixed, fmt.Errorf("saving question %d: %w", id, err)
+		}
+	}
+	return fixed, nil
+}
+
+// normalizeAttempt clamps the feedback of a stored attempt to the schema, reporting whether
+// anything changed. The p-score is only recomputed, against the given time target, when the
+// feedback was clamped or the score itself is out of range, so scores recorded with other
+// time targets are kept.
+func normalizeAttempt(a *types.Attempt, targetMinutes int) bool {
+	before := *a
+
+	a.HintsUsed = min(max(a.HintsUsed, 0), MaxHints)
+	switch {
+	case a.TimeTaken < UnsolvedTimeValue:
+		a.TimeTaken = UnsolvedTimeValue
+	case a.TimeTaken == 0:
+		a.TimeTaken = 1
+	case a.TimeTaken > MaxMinutes:
+		a.TimeTaken = MaxMinutes
+	}
+	a.Optimality = min(max(a.Optimality, MinRating), MaxRating)
+	a.Bugs = min(max(a.Bugs, MinRating), MaxRating)
+	if ValidateConfidence(a.Confidence) != nil {
+		a.Confidence = NoConfidence
+	}
+	a.FailureCategory = strings.ToLower(strings.TrimSpace(a.FailureCategory))
+	if ValidateFailureCategory(a.FailureCategory) != nil {
+		a.FailureCategory = ""
+	}
+	a.Approach = strings.TrimSpace(a.Approach)
+
+	clamped := a.HintsUsed != before.HintsUsed || a.TimeTaken != before.TimeTaken ||
+		a.Optimality != before.Optimality || a.Bugs != before.Bugs || a.Confidence != before.Confidence
+	if clamped || math.IsNaN(a.PScore) || a.PScore < 0 || a.PScore > 1 {
+		a.PScore = CalculatePScore(a.TimeTaken, targetMinutes, a.HintsUsed, a.Optimality, a.Bugs, a.Confidence)
+	}
+	return *a != before
+}
+
+// replay rebuilds the spaced repetition state of a question from its attempts, oldest first
+func replay(question types.Question, attempts []types.Attempt, targetMinutes int) types.Question {
+	question.AttemptCount = 0
+	question.Mastered = false
+	question.ReviewStreak = 0
+	question.ReviewInterval = 0
+	question.EasinessFactor = defaultEasinessFactor
+	question.LastPScore = 0
+	for _, a := range attempts {
+		ProcessReview(&question, a.TimeTaken, targetMinutes, a.HintsUsed, a.Optimality, a.Bugs, a.Confidence)
+	}
+	return question
+}
@@ -0,0 +1,130 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/config"
+	"dsacli/types"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFeedbackValidate(t *testing.T) {
+	valid := Feedback{HintsNeeded: 0, TimeTaken: 20, OptimalSolution: 4, AnyBugs: 5}
+
+	tests := []struct {
+		name    string
+		modify  func(f *Feedback)
+		wantErr bool
+	}{
+		{name: "Required fields only", modify: func(f *Feedback) {}},
+		{name: "All optional fields", modify: func(f *Feedback) {
+			f.Confidence = 3
+			f.SawSolution = true
+			f.FailureCategory = types.FailureEdgeCases
+			f.Approach = "two pointers"
+		}},
+		{name: "Negative hints", modify: func(f *Feedback) { f.HintsNeeded = -1 }, wantErr: true},
+		{name: "Unsolved", modify: func(f *Feedback) { f.TimeTaken = UnsolvedTimeValue }},
+		{name: "Failure category in another case", modify: func(f *Feedback) { f.FailureCategory = " Edge-Cases " }},
+		{name: "Too many hints", modify: func(f *Feedback) { f.HintsNeeded = MaxHints + 1 }, wantErr: true},
+		{name: "No time", modify: func(f *Feedback) { f.TimeTaken = 0 }, wantErr: true},
+		{name: "Too much time", modify: func(f *Feedback) { f.TimeTaken = MaxMinutes + 1 }, wantErr: true},
+		{name: "Below unsolved", modify: func(f *Feedback) { f.TimeTaken = -2 }, wantErr: true},
+		{name: "Optimality out of range", modify: func(f *Feedback) { f.OptimalSolution = 6 }, wantErr: true},
+		{name: "Bugs out of range", modify: func(f *Feedback) { f.AnyBugs = 0 }, wantErr: true},
+		{name: "Confidence out of range", modify: func(f *Feedback) { f.Confidence = 6 }, wantErr: true},
+		{name: "Negative confidence", modify: func(f *Feedback) { f.Confidence = -1 }, wantErr: true},
+		{name: "Unknown failure category", modify: func(f *Feedback) { f.FailureCategory = "typos" }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := valid
+			tt.modify(&f)
+			if err := f.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizeAttempt(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt types.Attempt
+		want    types.Attempt
+		changed bool
+	}{
+		{
+			name:    "Valid attempt",
+			attempt: types.Attempt{HintsUsed: 0, TimeTaken: 20, Optimality: 5, Bugs: 5, PScore: 1},
+			want:    types.Attempt{HintsUsed: 0, TimeTaken: 20, Optimality: 5, Bugs: 5, PScore: 1},
+		},
+		{
+			name:    "Negative hints made the p-score infinite",
+			attempt: types.Attempt{HintsUsed: -1, TimeTaken: 20, Optimality: 5, Bugs: 5, PScore: math.Inf(1)},
+			want:    types.Attempt{HintsUsed: 0, TimeTaken: 20, Optimality: 5, Bugs: 5, PScore: 1},
+			changed: true,
+		},
+		{
+			name:    "Out of range ratings and time",
+			attempt: types.Attempt{HintsUsed: 20, TimeTaken: 0, Optimality: 9, Bugs: 0, Confidence: 7, PScore: 0.5},
+			want:    types.Attempt{HintsUsed: MaxHints, TimeTaken: 1, Optimality: 5, Bugs: 1, PScore: CalculatePScore(1, 30, MaxHints, 5, 1, NoConfidence)},
+			changed: true,
+		},
+		{
+			name:    "Free-form fields",
+			attempt: types.Attempt{TimeTaken: UnsolvedTimeValue, Optimality: 1, Bugs: 1, PScore: 0.3, FailureCategory: " Pattern ", Approach: " dp "},
+			want:    types.Attempt{TimeTaken: UnsolvedTimeValue, Optimality: 1, Bugs: 1, PScore: 0.3, FailureCategory: types.FailurePattern, Approach: "dp"},
+			changed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.attempt
+			changed := normalizeAttempt(&got, 30)
+			if changed != tt.changed {
+				t.Errorf("normalizeAttempt() changed = %v, want %v", changed, tt.changed)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeAttempt() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeHistory(t *testing.T) {
+	now := time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)
+	service := newTestService(t, clock.Fixed(now), 1, 2)
+
+	if _, err := service.RecordAttempt(1, Feedback{TimeTaken: 20, OptimalSolution: 5, AnyBugs: 5}); err != nil {
+		t.Fatalf("RecordAttempt() unexpected error: %v", err)
+	}
+	// An attempt recorded before hints were validated
+	broken := types.Attempt{QuestionID: 2, Date: "2024-03-10", CompletedAt: now, HintsUsed: -1, TimeTaken: 40, Optimality: 3, Bugs: 3, PScore: math.Inf(1)}
+	if err := service.db.InsertAttempt(broken); err != nil {
+		t.Fatalf("InsertAttempt() unexpected error: %v", err)
+	}
+	question, _ := service.Question(2)
+	question.Attempted, question.AttemptCount, question.LastPScore = true, 1, math.Inf(1)
+	if err := service.db.UpdateQuestion(question); err != nil {
+		t.Fatalf("UpdateQuestion() unexpected error: %v", err)
+	}
+
+	fixed, err := service.NormalizeHistory()
+	if err != nil || fixed != 1 {
+		t.Fatalf("NormalizeHistory() = %d, %v, want 1 attempt fixed", fixed, err)
+	}
+	question, _ = service.Question(2)
+	want := CalculatePScore(40, config.DefaultTimeTargets().Easy, 0, 3, 3, NoConfidence)
+	if question.LastPScore != want || question.AttemptCount != 1 || question.ReviewInterval != 1 {
+		t.Errorf("NormalizeHistory() question = p-score %v, %d attempts, interval %d, want p-score %v after one attempt",
+			question.LastPScore, question.AttemptCount, question.ReviewInterval, want)
+	}
+
+	if fixed, err := service.NormalizeHistory(); err != nil || fixed != 0 {
+		t.Errorf("NormalizeHistory() again = %d, %v, want nothing to fix", fixed, err)
+	}
+}
@@ -0,0 +1,299 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/types"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+const (
+	// FinalWeekPhase is the last week before the interview, when only weak questions are reviewed
+	FinalWeekPhase = "final-week"
+
+	// FinalWeekDays is the length of the review-only stretch before the interview
+	FinalWeekDays = 7
+
+	// maxGoalIntake caps the new questions a day however close the interview is
+	maxGoalIntake = 5
+
+	// paceWindowDays is how far back attempts are counted to project readiness
+	paceWindowDays = 14
+)
+
+// ErrNoGoal is returned when clearing a goal that isn't set
+var ErrNoGoal = errors.New("no interview goal is set")
+
+// Readiness projects how much of the bank will be mastered by the interview
+type Readiness struct {
+	InterviewDate       string         `json:"interview_date"`
+	DaysLeft            int            `json:"days_left"`
+	MasteredPercentage  float64        `json:"mastered_percentage"`
+	ProjectedPercentage float64        `json:"projected_percentage"`
+	Focus               []TagReadiness `json:"focus,omitempty"`
+}
+
+// TagReadiness is the mastery of the questions with one of the goal's focus tags
+type TagReadiness struct {
+	Tag      string `json:"tag"`
+	Total    int    `json:"total"`
+	Mastered int    `json:"mastered"`
+}
+
+// ActiveGoal returns the interview goal while the interview is still ahead
+func (s *Service) ActiveGoal() (types.Goal, bool, error) {
+	goal, ok, err := s.db.GetGoal()
+	if err != nil {
+		return types.Goal{}, false, fmt.Errorf("loading goal: %w", err)
+	}
+	if !ok || goal.InterviewDate < s.Date() {
+		return types.Goal{}, false, nil
+	}
+	return goal, true, nil
+}
+
+// SetGoal sets the interview date and focus tags, and shortens the review intervals that
+// would otherwise only come due after the final week has started
+func (s *Service) SetGoal(interview string, focus []string) (types.Goal, error) {
+	if _, err := clock.ParseDate(interview); err != nil {
+		return types.Goal{}, err
+	}
+	today := s.Date()
+	if interview <= today {
+		return types.Goal{}, fmt.Errorf("interview must be after today (%s)", today)
+	}
+
+	goal := types.Goal{InterviewDate: interview, Focus: focus, SetDate: today}
+	if err := s.db.SetGoal(goal); err != nil {
+		return types.Goal{}, fmt.Errorf("saving goal: %w", err)
+	}
+
+	attempted, err := s.db.GetAllAttemptedQuestions()
+	if err != nil {
+		return types.Goal{}, fmt.Errorf("loading attempted questions: %w", err)
+	}
+	deadline := reviewDeadline(goal)
+	for _, q := range attempted {
+		if q.LastReviewed == nil {
+			continue
+		}
+		interval := q.ReviewInterval
+		if err := capInterval(&q, s.calendar.Date(*q.LastReviewed), deadline); err != nil {
+			return types.Goal{}, err
+		}
+		if q.ReviewInterval == interval {
+			continue
+		}
+		if err := s.db.UpdateQuestion(q); err != nil {
+			return types.Goal{}, fmt.Errorf("capping review interval: %w", err)
+		}
+	}
+	return goal, nil
+}
+
+// ClearGoal removes the interview goal. Capped intervals grow back with the next reviews.
+func (s *Service) ClearGoal() error {
+	if _, ok, err := s.db.GetGoal(); err != nil {
+		return fmt.Errorf("loading goal: %w", err)
+	} else if !ok {
+		return ErrNoGoal
+	}
+	return s.db.ClearGoal()
+}
+
+// daysLeft returns the number of days from today until the interview
+func (s *Service) daysLeft(goal types.Goal) (int, error) {
+	return clock.DaysBetween(s.Date(), goal.InterviewDate)
+}
+
+// reviewDeadline returns the first day of the final week, by which every reviewed
+// question should have come due again
+func reviewDeadline(goal types.Goal) string {
+	interview, err := clock.ParseDate(goal.InterviewDate)
+	if err != nil {
+		return goal.InterviewDate
+	}
+	return interview.AddDate(0, 0, -FinalWeekDays).Format(clock.DateLayout)
+}
+
+// capInterval shortens the review interval of a question reviewed on the given day so
+// that it comes due by the deadline, or the day after the review once it has passed. Days
+// a pause pushed the review back by are kept.
+func capInterval(question *types.Question, reviewed, deadline string) error {
+	days, err := clock.DaysBetween(reviewed, deadline)
+	if err != nil {
+		return err
+	}
+	days = max(days-question.DueShift, 1)
+	if question.ReviewInterval > days {
+		question.ReviewInterval = days
+	}
+	return nil
+}
+
+// goalIntake returns how many new questions a day keep the goal on track. The unattempted
+// questions are spread over the first three quarters of the days before the final week,
+// which front-loads the intake and leaves time to consolidate.
+func goalIntake(daysLeft, unattempted int) int {
+	days := daysLeft - FinalWeekDays
+	if days <= 0 || unattempted == 0 {
+		return 0
+	}
+	days = max(days*3/4, 1)
+	return min((unattempted+days-1)/days, maxGoalIntake)
+}
+
+// hasFocus reports whether the question has one of the focus tags
+func hasFocus(question types.Question, focus []string) bool {
+	for _, tag := range question.Tags {
+		for _, f := range focus {
+			if strings.EqualFold(tag, f) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyGoal adjusts the generated questions to the interview goal. In the final week they are
+// replaced by as many weak questions to review; before that the plan is topped up with new
+// questions, focus tags first, until it keeps up with the intake the deadline needs. The picks
+// it makes are recorded in the trace.
+func (s *Service) applyGoal(rng *rand.Rand, goal types.Goal, questions []types.Question, phase string, ignore []uint, trace *Trace) ([]types.Question, string, error) {
+	daysLeft, err := s.daysLeft(goal)
+	if err != nil {
+		return nil, "", err
+	}
+	active, err := s.db.GetActiveQuestions()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load questions: %w", err)
+	}
+	active = filterOutQuestions(active, ignore)
+
+	if daysLeft <= FinalWeekDays {
+		var weak []types.Question
+		for _, q := range active {
+			if q.Attempted && !q.Mastered {
+				weak = append(weak, q)
+			}
+		}
+		sort.SliceStable(weak, func(i, j int) bool {
+			fi, fj := hasFocus(weak[i], goal.Focus), hasFocus(weak[j], goal.Focus)
+			if fi != fj {
+				return fi
+			}
+			return weak[i].LastPScore < weak[j].LastPScore
+		})
+		count, pool := max(len(questions), questionsPerDay), len(weak)
+		weak = weak[:min(count, len(weak))]
+		for _, q := range weak {
+			trace.pick(q, pool, q.LastPScore, fmt.Sprintf("weak question for the final week, p-score %.2f", q.LastPScore))
+			traceFocus(trace, q, goal.Focus)
+		}
+		return weak, FinalWeekPhase, nil
+	}
+
+	planned := make(map[uint]bool)
+	fresh := 0
+	for _, q := range questions {
+		planned[q.ID] = true
+		if !q.Attempted {
+			fresh++
+		}
+	}
+
+	var unattempted []types.Question
+	for _, q := range active {
+		if !q.Attempted && !planned[q.ID] {
+			unattempted = append(unattempted, q)
+		}
+	}
+	need := goalIntake(daysLeft, len(unattempted)+fresh) - fresh
+	if need <= 0 {
+		return questions, phase, nil
+	}
+
+	rng.Shuffle(len(unattempted), func(i, j int) { unattempted[i], unattempted[j] = unattempted[j], unattempted[i] })
+	tier := make(map[string]int)
+	for i, difficulty := range types.Difficulties {
+		tier[difficulty] = i
+	}
+	sort.SliceStable(unattempted, func(i, j int) bool {
+		fi, fj := hasFocus(unattempted[i], goal.Focus), hasFocus(unattempted[j], goal.Focus)
+		if fi != fj {
+			return fi
+		}
+		return tier[unattempted[i].Difficulty] < tier[unattempted[j].Difficulty]
+	})
+	extra := unattempted[:min(need, len(unattempted))]
+	for _, q := range extra {
+		trace.pick(q, len(unattempted), 0, fmt.Sprintf("extra new question to cover the bank in %d day(s)", daysLeft))
+		traceFocus(trace, q, goal.Focus)
+	}
+	return append(questions, extra...), phase, nil
+}
+
+// traceFocus notes in the trace when a question was picked for having a focus tag
+func traceFocus(trace *Trace, q types.Question, focus []string) {
+	if hasFocus(q, focus) {
+		trace.reason(q.ID, "has a focus tag")
+	}
+}
+
+// readiness projects the mastery at the interview from the current mastery and the pace
+// of the last two weeks, assuming attempts keep turning into masteries at the same rate
+func (s *Service) readiness(goal types.Goal, questions []types.Question, attempts []types.Attempt) (Readiness, error) {
+	daysLeft, err := s.daysLeft(goal)
+	if err != nil {
+		return Readiness{}, err
+	}
+	readiness := Readiness{InterviewDate: goal.InterviewDate, DaysLeft: daysLeft}
+	if len(questions) == 0 {
+		return readiness, nil
+	}
+
+	mastered := 0
+	byTag := make(map[string]*TagReadiness)
+	for _, tag := range goal.Focus {
+		byTag[strings.ToLower(tag)] = &TagReadiness{Tag: tag}
+	}
+	for _, q := range questions {
+		if q.Mastered {
+			mastered++
+		}
+		for _, tag := range q.Tags {
+			if tr, ok := byTag[strings.ToLower(tag)]; ok {
+				tr.Total++
+				if q.Mastered {
+					tr.Mastered++
+				}
+			}
+		}
+	}
+	for _, tag := range goal.Focus {
+		readiness.Focus = append(readiness.Focus, *byTag[strings.ToLower(tag)])
+	}
+
+	since := s.calendar.Day(s.Now()).AddDate(0, 0, -(paceWindowDays - 1)).Format(clock.DateLayout)
+	recent := 0
+	for _, a := range attempts {
+		if a.Date >= since {
+			recent++
+		}
+	}
+
+	projected := float64(mastered)
+	if len(attempts) > 0 {
+		perAttempt := float64(mastered) / float64(len(attempts))
+		pace := float64(recent) / paceWindowDays
+		projected += pace * float64(daysLeft) * perAttempt
+	}
+	total := float64(len(questions))
+	readiness.MasteredPercentage = float64(mastered) / total * 100
+	readiness.ProjectedPercentage = math.Min(projected, total) / total * 100
+	return readiness, nil
+}
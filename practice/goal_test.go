@@ -0,0 +1,137 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/types"
+	"testing"
+	"time"
+)
+
+func TestGoalIntake(t *testing.T) {
+	tests := []struct {
+		name        string
+		daysLeft    int
+		unattempted int
+		expected    int
+	}{
+		{name: "Final week", daysLeft: 7, unattempted: 20, expected: 0},
+		{name: "Nothing left to learn", daysLeft: 30, unattempted: 0, expected: 0},
+		{name: "Spread over three quarters of the time", daysLeft: 47, unattempted: 60, expected: 2},
+		{name: "Capped", daysLeft: 9, unattempted: 100, expected: maxGoalIntake},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := goalIntake(tt.daysLeft, tt.unattempted); got != tt.expected {
+				t.Errorf("goalIntake(%d, %d) = %d, want %d", tt.daysLeft, tt.unattempted, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCapInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval int
+		reviewed string
+		expected int
+	}{
+		{name: "Due before the deadline", interval: 3, reviewed: "2024-03-01", expected: 3},
+		{name: "Due after the deadline", interval: 30, reviewed: "2024-03-01", expected: 9},
+		{name: "Deadline passed", interval: 6, reviewed: "2024-03-12", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			question := types.Question{ReviewInterval: tt.interval}
+			if err := capInterval(&question, tt.reviewed, "2024-03-10"); err != nil {
+				t.Fatalf("capInterval() unexpected error: %v", err)
+			}
+			if question.ReviewInterval != tt.expected {
+				t.Errorf("ReviewInterval = %d, want %d", question.ReviewInterval, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPlanTodayGoal(t *testing.T) {
+	clk := clock.NewManual(time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local))
+	service := newTestService(t, clk, 1, 20)
+
+	// Leave one question weak and master another
+	weak := Feedback{HintsNeeded: 3, TimeTaken: UnsolvedTimeValue, OptimalSolution: 1, AnyBugs: 1}
+	strong := Feedback{HintsNeeded: 0, TimeTaken: 10, OptimalSolution: MaxRating, AnyBugs: MaxRating}
+	if _, err := service.RecordAttempt(1, weak); err != nil {
+		t.Fatalf("RecordAttempt() unexpected error: %v", err)
+	}
+	if _, err := service.RecordAttempt(2, strong); err != nil {
+		t.Fatalf("RecordAttempt() unexpected error: %v", err)
+	}
+
+	clk.Advance(24 * time.Hour)
+	if _, err := service.SetGoal("2024-03-14", nil); err != nil {
+		t.Fatalf("SetGoal() unexpected error: %v", err)
+	}
+
+	plan, err := service.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	// 18 new questions over 3 of the 5 days before the final week
+	if len(plan.Questions) != maxGoalIntake || plan.InterviewIn != 12 {
+		t.Errorf("Expected %d questions 12 days before the interview, got %d and %d days", maxGoalIntake, len(plan.Questions), plan.InterviewIn)
+	}
+
+	clk.Advance(6 * 24 * time.Hour)
+	plan, err = service.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	if plan.Phase != FinalWeekPhase {
+		t.Fatalf("Expected the final week phase, got %q", plan.Phase)
+	}
+	if ids := planIDs(plan); len(ids) != 1 || ids[0] != 1 {
+		t.Errorf("Expected only the weak question to be reviewed, got %v", ids)
+	}
+
+	status, err := service.Status()
+	if err != nil {
+		t.Fatalf("Status() unexpected error: %v", err)
+	}
+	if status.Readiness == nil || status.Readiness.DaysLeft != 6 {
+		t.Errorf("Expected readiness 6 days before the interview, got %+v", status.Readiness)
+	}
+}
+
+func TestSetGoalCapsIntervals(t *testing.T) {
+	clk := clock.NewManual(time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local))
+	service := newTestService(t, clk, 1, 1)
+
+	feedback := Feedback{HintsNeeded: 0, TimeTaken: 10, OptimalSolution: MaxRating, AnyBugs: MaxRating}
+	if _, err := service.RecordAttempt(1, feedback); err != nil {
+		t.Fatalf("RecordAttempt() unexpected error: %v", err)
+	}
+	question, err := service.Question(1)
+	if err != nil {
+		t.Fatalf("Question() unexpected error: %v", err)
+	}
+	question.ReviewInterval = 40
+	if err := service.db.UpdateQuestion(question); err != nil {
+		t.Fatalf("UpdateQuestion() unexpected error: %v", err)
+	}
+
+	if _, err := service.SetGoal("2024-03-20", []string{"graphs"}); err != nil {
+		t.Fatalf("SetGoal() unexpected error: %v", err)
+	}
+	question, err = service.Question(1)
+	if err != nil {
+		t.Fatalf("Question() unexpected error: %v", err)
+	}
+	if question.ReviewInterval != 12 {
+		t.Errorf("Expected the interval to be capped to the final week, got %d days", question.ReviewInterval)
+	}
+
+	if _, err := service.SetGoal("2024-03-01", nil); err == nil {
+		t.Error("Expected an interview date in the past to be rejected")
+	}
+}
@@ -0,0 +1,113 @@
+package practice
+
+import (
+	"dsacli/types"
+	"fmt"
+)
+
+// Graph is the prerequisite graph of the question bank: the questions that have or are a
+// prerequisite, and the dependencies between them
+type Graph struct {
+	Questions    []types.Question           `json:"questions"`
+	Dependencies []types.QuestionDependency `json:"dependencies"`
+}
+
+// Graph returns the prerequisite graph, questions in ID order
+func (s *Service) Graph() (Graph, error) {
+	dependencies, err := s.db.GetDependencies()
+	if err != nil {
+		return Graph{}, fmt.Errorf("loading prerequisites: %w", err)
+	}
+	questions, err := s.db.GetAllQuestions()
+	if err != nil {
+		return Graph{}, fmt.Errorf("loading questions: %w", err)
+	}
+
+	inGraph := make(map[uint]bool)
+	for _, d := range dependencies {
+		inGraph[d.QuestionID] = true
+		inGraph[d.PrerequisiteID] = true
+	}
+	graph := Graph{Questions: []types.Question{}, Dependencies: dependencies}
+	for _, q := range questions {
+		if inGraph[q.ID] {
+			graph.Questions = append(graph.Questions, q)
+		}
+	}
+	return graph, nil
+}
+
+// neighbours returns the prerequisites and the dependents of a question
+func (s *Service) neighbours(id uint) ([]types.Question, []types.Question, error) {
+	dependencies, err := s.db.GetDependencies()
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading prerequisites: %w", err)
+	}
+
+	prereqs, dependents := []types.Question{}, []types.Question{}
+	for _, d := range dependencies {
+		if d.QuestionID != id && d.PrerequisiteID != id {
+			continue
+		}
+		other := d.PrerequisiteID
+		if other == id {
+			other = d.QuestionID
+		}
+		q, err := s.Question(other)
+		if err != nil {
+			return nil, nil, err
+		}
+		if d.QuestionID == id {
+			prereqs = append(prereqs, q)
+		} else {
+			dependents = append(dependents, q)
+		}
+	}
+	return prereqs, dependents, nil
+}
+
+// prerequisites returns the prerequisites of every question that has any
+func prerequisites(dependencies []types.QuestionDependency) map[uint][]uint {
+	prereqs := make(map[uint][]uint)
+	for _, d := range dependencies {
+		prereqs[d.QuestionID] = append(prereqs[d.QuestionID], d.PrerequisiteID)
+	}
+	return prereqs
+}
+
+// blockedQuestions returns the IDs of the unattempted questions with a prerequisite that wasn't
+// attempted yet. Prerequisites missing from the questions, e.g. archived ones, block nothing.
+func blockedQuestions(questions []types.Question, dependencies []types.QuestionDependency) []uint {
+	attempted := make(map[uint]bool, len(questions))
+	for _, q := range questions {
+		attempted[q.ID] = q.Attempted
+	}
+
+	var blocked []uint
+	prereqs := prerequisites(dependencies)
+	for _, q := range questions {
+		if q.Attempted {
+			continue
+		}
+		for _, id := range prereqs[q.ID] {
+			if done, ok := attempted[id]; ok && !done {
+				blocked = append(blocked, q.ID)
+				break
+			}
+		}
+	}
+	return blocked
+}
+
+// blocked returns the active questions held back by their prerequisites, see blockedQuestions
+func (s *Service) blocked() ([]uint, error) {
+	questions, err := s.db.GetActiveQuestions()
+	if err != nil {
+		return nil, fmt.Errorf("loading questions: %w", err)
+	}
+	dependencies, err := s.db.GetDependencies()
+	if err != nil {
+		return nil, fmt.Errorf("loading prerequisites: %w", err)
+	}
+	return blockedQuestions(questions, dependencies), nil
+}
@@ -0,0 +1,90 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/types"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBlockedQuestions(t *testing.T) {
+	dependencies := []types.QuestionDependency{
+		{QuestionID: 2, PrerequisiteID: 1},
+		{QuestionID: 3, PrerequisiteID: 1},
+		{QuestionID: 3, PrerequisiteID: 4},
+		{QuestionID: 5, PrerequisiteID: 6}, // 6 is archived and left out
+	}
+
+	tests := []struct {
+		name      string
+		questions []types.Question
+		want      []uint
+	}{
+		{
+			name:      "Prerequisite not attempted",
+			questions: []types.Question{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4, Attempted: true}, {ID: 5}},
+			want:      []uint{2, 3},
+		},
+		{
+			name:      "One of two prerequisites attempted",
+			questions: []types.Question{{ID: 1, Attempted: true}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}},
+			want:      []uint{3},
+		},
+		{
+			name:      "Attempted questions are never blocked",
+			questions: []types.Question{{ID: 1}, {ID: 2, Attempted: true}, {ID: 3, Attempted: true}, {ID: 4}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := blockedQuestions(tt.questions, dependencies); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("blockedQuestions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanTodayRespectsPrerequisites(t *testing.T) {
+	clk := clock.NewManual(time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local))
+	service := newTestService(t, clk, 1, 3)
+	// q2 and q3 build on q1
+	dependencies := []types.QuestionDependency{{QuestionID: 2, PrerequisiteID: 1}, {QuestionID: 3, PrerequisiteID: 1}}
+	if err := service.db.InsertDependencies(dependencies); err != nil {
+		t.Fatalf("InsertDependencies() unexpected error: %v", err)
+	}
+
+	plan, err := service.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	if len(plan.Questions) != 1 || plan.Questions[0].Question.ID != 1 || plan.Trace.Blocked != 2 {
+		t.Fatalf("PlanToday() = %d question(s), %d blocked, want only q1 with 2 blocked", len(plan.Questions), plan.Trace.Blocked)
+	}
+
+	if _, err := service.RecordAttempt(1, Feedback{TimeTaken: 10, OptimalSolution: 5, AnyBugs: 5}); err != nil {
+		t.Fatalf("RecordAttempt() unexpected error: %v", err)
+	}
+	clk.Advance(24 * time.Hour)
+	plan, err = service.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	for _, q := range plan.Questions {
+		if q.Question.ID == 1 {
+			t.Errorf("PlanToday() planned q1 again, want its dependents")
+		}
+	}
+	if len(plan.Questions) != 2 || plan.Trace.Blocked != 0 {
+		t.Errorf("PlanToday() = %d question(s), %d blocked, want both dependents", len(plan.Questions), plan.Trace.Blocked)
+	}
+
+	details, err := service.Details(1)
+	if err != nil {
+		t.Fatalf("Details() unexpected error: %v", err)
+	}
+	if len(details.Prerequisites) != 0 || len(details.Dependents) != 2 {
+		t.Errorf("Details() = %d prerequisites, %d dependents, want 0 and 2", len(details.Prerequisites), len(details.Dependents))
+	}
+}
@@ -0,0 +1,224 @@
+package practice
+
+import (
+	"dsacli/types"
+	"fmt"
+	"sort"
+)
+
+// maxEasierQuestions is how many easier questions are suggested for a leech
+const maxEasierQuestions = 3
+
+// Leech is a question that keeps failing its recalls, along with its prerequisites and easier
+// questions sharing a tag that are worth practicing first
+type Leech struct {
+	Question types.Question   `json:"question"`
+	Failures int              `json:"failures"` // failed recalls over its whole history
+	Easier   []types.Question `json:"easier"`
+}
+
+// failedRecalls counts the failed recalls of each question over its whole history
+func failedRecalls(attempts []types.Attempt) map[uint]int {
+	failures := make(map[uint]int)
+	for _, a := range attempts {
+		if a.PScore < recallThreshold {
+			failures[a.QuestionID]++
+		}
+	}
+	return failures
+}
+
+// isLeech reports whether a question failed enough recalls to be a leech. Mastered questions
+// got unstuck and a threshold of 0 disables detection.
+func isLeech(question types.Question, failures, threshold int) bool {
+	return threshold > 0 && failures >= threshold && !question.Mastered
+}
+
+// tagLeech adds the leech tag to a leech and removes it once the question isn't one anymore,
+// reporting whether the tags changed
+func tagLeech(question *types.Question, leech bool) bool {
+	if leech == question.HasTag(types.LeechTag) {
+		return false
+	}
+	if leech {
+		question.Tags = append(question.Tags, types.LeechTag)
+		return true
+	}
+	tags := make([]string, 0, len(question.Tags))
+	for _, tag := range question.Tags {
+		if tag != types.LeechTag {
+			tags = append(tags, tag)
+		}
+	}
+	question.Tags = tags
+	return true
+}
+
+// easierQuestions returns the prerequisites of the leech followed by questions of a lower
+// difficulty sharing a tag with it, the ones sharing the most tags first and unmastered ones
+// before mastered ones
+func easierQuestions(leech types.Question, questions []types.Question, prereqs []uint) []types.Question {
+	isPrereq := make(map[uint]bool, len(prereqs))
+	for _, id := range prereqs {
+		isPrereq[id] = true
+	}
+
+	rank := func(difficulty string) int {
+		for i, d := range types.Difficulties {
+			if d == difficulty {
+				return i
+			}
+		}
+		return len(types.Difficulties)
+	}
+
+	type candidate struct {
+		question types.Question
+		shared   int
+	}
+	var candidates []candidate
+	for _, q := range questions {
+		if q.ID == leech.ID || q.Archived || q.Suspended || (!isPrereq[q.ID] && rank(q.Difficulty) >= rank(leech.Difficulty)) {
+			continue
+		}
+		shared := 0
+		for _, tag := range leech.Tags {
+			if tag != types.LeechTag && q.HasTag(tag) {
+				shared++
+			}
+		}
+		if shared > 0 || isPrereq[q.ID] {
+			candidates = append(candidates, candidate{q, shared})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if isPrereq[a.question.ID] != isPrereq[b.question.ID] {
+			return isPrereq[a.question.ID]
+		}
+		if a.shared != b.shared {
+			return a.shared > b.shared
+		}
+		if a.question.Mastered != b.question.Mastered {
+			return !a.question.Mastered
+		}
+		return a.question.ID < b.question.ID
+	})
+
+	easier := make([]types.Question, 0, maxEasierQuestions)
+	for _, c := range candidates {
+		if len(easier) == maxEasierQuestions {
+			break
+		}
+		easier = append(easier, c.question)
+	}
+	return easier
+}
+
+// Leeches returns the questions that failed at least the configured number of recalls,
+// suspended ones included, the most failed first
+func (s *Service) Leeches() ([]Leech, error) {
+	questions, err := s.db.GetAllQuestions()
+	if err != nil {
+		return nil, fmt.Errorf("loading questions: %w", err)
+	}
+	attempts, err := s.db.GetAttempts()
+	if err != nil {
+		return nil, fmt.Errorf("loading attempts: %w", err)
+	}
+	failures := failedRecalls(attempts)
+	dependencies, err := s.db.GetDependencies()
+	if err != nil {
+		return nil, fmt.Errorf("loading prerequisites: %w", err)
+	}
+	prereqs := prerequisites(dependencies)
+
+	leeches := []Leech{}
+	for _, q := range questions {
+		if q.Archived || !isLeech(q, failures[q.ID], s.settings.LeechThreshold) {
+			continue
+		}
+		leeches = append(leeches, Leech{Question: q, Failures: failures[q.ID], Easier: easierQuestions(q, questions, prereqs[q.ID])})
+	}
+	sort.SliceStable(leeches, func(i, j int) bool {
+		return leeches[i].Failures > leeches[j].Failures
+	})
+	return leeches, nil
+}
+
+// tagLeeches adds the leech tag to the leeches that don't have it yet, e.g. the ones that failed
+// before detection was added, and returns how many were tagged
+func (s *Service) tagLeeches() (int, error) {
+	leeches, err := s.Leeches()
+	if err != nil {
+		return 0, err
+	}
+	tagged := 0
+	for _, l := range leeches {
+		if !tagLeech(&l.Question, true) {
+			continue
+		}
+		if err := s.db.UpdateQuestion(l.Question); err != nil {
+			return tagged, fmt.Errorf("tagging leech %d: %w", l.Question.ID, err)
+		}
+		tagged++
+	}
+	return tagged, nil
+}
+
+// leech checks whether a question just recorded an attempt is a leech, updating its tag. It
+// returns the leech if the attempt made it one.
+func (s *Service) leech(question *types.Question) (*Leech, error) {
+	attempts, err := s.db.GetAttempts()
+	if err != nil {
+		return nil, fmt.Errorf("loading attempts: %w", err)
+	}
+	failures := failedRecalls(attempts)[question.ID]
+	if question.LastPScore < recallThreshold {
+		failures++
+	}
+
+	leech := isLeech(*question, failures, s.settings.LeechThreshold)
+	if !tagLeech(question, leech) || !leech {
+		return nil, nil
+	}
+	questions, err := s.db.GetActiveQuestions()
+	if err != nil {
+		return nil, fmt.Errorf("loading questions: %w", err)
+	}
+	dependencies, err := s.db.GetDependencies()
+	if err != nil {
+		return nil, fmt.Errorf("loading prerequisites: %w", err)
+	}
+	prereqs := prerequisites(dependencies)[question.ID]
+	return &Leech{Question: *question, Failures: failures, Easier: easierQuestions(*question, questions, prereqs)}, nil
+}
+
+// SuspendQuestion stops scheduling a question until it is resumed
+func (s *Service) SuspendQuestion(id uint) (types.Question, error) {
+	return s.setSuspended(id, true)
+}
+
+// ResumeQuestion schedules a suspended question again
+func (s *Service) ResumeQuestion(id uint) (types.Question, error) {
+	return s.setSuspended(id, false)
+}
+
+func (s *Service) setSuspended(id uint, suspended bool) (types.Question, error) {
+	question, err := s.Question(id)
+	if err != nil {
+		return types.Question{}, err
+	}
+	if question.Suspended == suspended {
+		state := "isn't suspended"
+		if suspended {
+			state = "is already suspended"
+		}
+		return types.Question{}, fmt.Errorf("question %d %s", id, state)
+	}
+	question.Suspended = suspended
+	if err := s.db.UpdateQuestion(question); err != nil {
+		return types.Question{}, fmt.Errorf("saving question: %w", err)
+	}
+	return question, nil
+}
@@ -0,0 +1,126 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/types"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIsLeech(t *testing.T) {
+	tests := []struct {
+		name      string
+		question  types.Question
+		failures  int
+		threshold int
+		want      bool
+	}{
+		{name: "Below the threshold", failures: 3, threshold: 4},
+		{name: "At the threshold", failures: 4, threshold: 4, want: true},
+		{name: "Mastered despite failures", question: types.Question{Mastered: true}, failures: 6, threshold: 4},
+		{name: "Detection disabled", failures: 10, threshold: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isLeech(tt.question, tt.failures, tt.threshold); got != tt.want {
+				t.Errorf("isLeech() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagLeech(t *testing.T) {
+	q := types.Question{Tags: []string{"graphs"}}
+	if !tagLeech(&q, true) || !reflect.DeepEqual(q.Tags, []string{"graphs", types.LeechTag}) {
+		t.Errorf("tagLeech(true) tags = %v, want the leech tag added", q.Tags)
+	}
+	if tagLeech(&q, true) {
+		t.Errorf("tagLeech(true) on a tagged leech reported a change")
+	}
+	if !tagLeech(&q, false) || !reflect.DeepEqual(q.Tags, []string{"graphs"}) {
+		t.Errorf("tagLeech(false) tags = %v, want the leech tag removed", q.Tags)
+	}
+}
+
+func TestEasierQuestions(t *testing.T) {
+	leech := types.Question{ID: 1, Difficulty: types.DifficultyMedium, Tags: []string{"graphs", "bfs", types.LeechTag}}
+	questions := []types.Question{
+		leech,
+		{ID: 2, Difficulty: types.DifficultyEasy, Tags: []string{"graphs"}, Mastered: true},
+		{ID: 3, Difficulty: types.DifficultyEasy, Tags: []string{"graphs"}},
+		{ID: 4, Difficulty: types.DifficultyEasy, Tags: []string{"graphs", "bfs"}, Mastered: true},
+		{ID: 5, Difficulty: types.DifficultyMedium, Tags: []string{"graphs"}},
+		{ID: 6, Difficulty: types.DifficultyEasy, Tags: []string{"arrays", types.LeechTag}},
+		{ID: 7, Difficulty: types.DifficultyEasy, Tags: []string{"bfs"}, Suspended: true},
+		{ID: 8, Difficulty: types.DifficultyEasy, Tags: []string{"bfs"}},
+		{ID: 9, Difficulty: types.DifficultyMedium, Tags: []string{"matrix"}},
+	}
+
+	tests := []struct {
+		name    string
+		prereqs []uint
+		want    []uint
+	}{
+		// Most shared tags first, then the ones still to master
+		{name: "Easier questions sharing a tag", want: []uint{4, 3, 8}},
+		{name: "Prerequisites first", prereqs: []uint{9}, want: []uint{9, 4, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []uint
+			for _, q := range easierQuestions(leech, questions, tt.prereqs) {
+				got = append(got, q.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("easierQuestions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordAttemptDetectsLeech(t *testing.T) {
+	service := newTestService(t, clock.Fixed(time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)), 1, 1)
+	failed := Feedback{TimeTaken: UnsolvedTimeValue, HintsNeeded: 3, OptimalSolution: 1, AnyBugs: 1}
+
+	threshold := service.settings.LeechThreshold
+	for i := 1; i <= threshold+1; i++ {
+		result, err := service.RecordAttempt(1, failed)
+		if err != nil {
+			t.Fatalf("RecordAttempt() unexpected error: %v", err)
+		}
+		if (result.Leech != nil) != (i == threshold) {
+			t.Errorf("RecordAttempt() failure %d leech = %v, want it only on failure %d", i, result.Leech, threshold)
+		}
+		if got, want := result.Question.HasTag(types.LeechTag), i >= threshold; got != want {
+			t.Errorf("RecordAttempt() failure %d tagged = %v, want %v", i, got, want)
+		}
+	}
+
+	leeches, err := service.Leeches()
+	if err != nil || len(leeches) != 1 || leeches[0].Failures != threshold+1 {
+		t.Fatalf("Leeches() = %+v, %v, want the question with %d failures", leeches, err, threshold+1)
+	}
+
+	if _, err := service.SuspendQuestion(1); err != nil {
+		t.Fatalf("SuspendQuestion() unexpected error: %v", err)
+	}
+	if active, _ := service.db.GetActiveQuestions(); len(active) != 0 {
+		t.Errorf("GetActiveQuestions() = %d questions, want the suspended question left out", len(active))
+	}
+	if _, err := service.SuspendQuestion(1); err == nil {
+		t.Errorf("SuspendQuestion() of a suspended question expected error")
+	}
+	if leeches, _ := service.Leeches(); len(leeches) != 1 || !leeches[0].Question.Suspended {
+		t.Errorf("Leeches() = %+v, want the suspended leech listed", leeches)
+	}
+
+	if _, err := service.ResumeQuestion(1); err != nil {
+		t.Fatalf("ResumeQuestion() unexpected error: %v", err)
+	}
+	if active, _ := service.db.GetActiveQuestions(); len(active) != 1 {
+		t.Errorf("GetActiveQuestions() = %d questions, want the resumed question back", len(active))
+	}
+}
@@ -0,0 +1,57 @@
+package practice
+
+import "fmt"
+
+// Names of the data migrations, recorded once they ran
+const (
+	// normalizeHistoryMigration clamps the feedback stored before it was validated
+	normalizeHistoryMigration = "normalize-feedback-history"
+	// tagLeechesMigration tags the leeches that failed before leeches were detected
+	tagLeechesMigration = "tag-leeches"
+)
+
+// Migrated is what the data migrations run by Migrate changed
+type Migrated struct {
+	// FixedAttempts is the number of attempts whose out of range feedback was clamped
+	FixedAttempts int
+	// TaggedLeeches is the number of questions that got the leech tag
+	TaggedLeeches int
+}
+
+// Migrate applies the data migrations that haven't run on this database yet. Each one is recorded
+// once applied, so later runs don't go through the whole history again.
+func (s *Service) Migrate() (Migrated, error) {
+	var migrated Migrated
+	err := s.migrateOnce(normalizeHistoryMigration, func() error {
+		fixed, err := s.NormalizeHistory()
+		migrated.FixedAttempts = fixed
+		return err
+	})
+	if err != nil {
+		return migrated, err
+	}
+	err = s.migrateOnce(tagLeechesMigration, func() error {
+		tagged, err := s.tagLeeches()
+		migrated.TaggedLeeches = tagged
+		return err
+	})
+	return migrated, err
+}
+
+// migrateOnce runs the named migration unless it was already applied and records it
+func (s *Service) migrateOnce(name string, migrate func() error) error {
+	applied, err := s.db.MigrationApplied(name)
+	if err != nil {
+		return fmt.Errorf("checking migration %s: %w", name, err)
+	}
+	if applied {
+		return nil
+	}
+	if err := migrate(); err != nil {
+		return fmt.Errorf("migration %s: %w", name, err)
+	}
+	if err := s.db.MarkMigrationApplied(name); err != nil {
+		return fmt.Errorf("recording migration %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/types"
+	"testing"
+	"time"
+)
+
+func TestMigrateRunsOnce(t *testing.T) {
+	now := time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)
+	service := newTestService(t, clock.Fixed(now), 1, 2)
+
+	broken := types.Attempt{QuestionID: 1, Date: "2024-03-10", CompletedAt: now, HintsUsed: -1, TimeTaken: 40, Optimality: 3, Bugs: 3}
+	if err := service.db.InsertAttempt(broken); err != nil {
+		t.Fatalf("InsertAttempt() unexpected error: %v", err)
+	}
+	migrated, err := service.Migrate()
+	if err != nil || migrated.FixedAttempts != 1 {
+		t.Fatalf("Migrate() = %+v, %v, want 1 attempt fixed", migrated, err)
+	}
+
+	// Feedback broken after the migration ran is left to the validation of new attempts
+	broken.QuestionID = 2
+	if err := service.db.InsertAttempt(broken); err != nil {
+		t.Fatalf("InsertAttempt() unexpected error: %v", err)
+	}
+	if migrated, err := service.Migrate(); err != nil || migrated.FixedAttempts != 0 {
+		t.Errorf("Migrate() again = %+v, %v, want the migration not to run again", migrated, err)
+	}
+}
+
+func TestMigrateTagsLeeches(t *testing.T) {
+	now := time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)
+	service := newTestService(t, clock.Fixed(now), 1, 2)
+
+	// Failed recalls recorded before leeches were detected
+	question, _ := service.Question(1)
+	question.Attempted = true
+	for i := 0; i < service.settings.LeechThreshold; i++ {
+		failed := types.Attempt{QuestionID: 1, Date: "2024-03-10", CompletedAt: now, HintsUsed: 3, TimeTaken: UnsolvedTimeValue, Optimality: 1, Bugs: 1, PScore: 0.1}
+		if err := service.db.InsertAttempt(failed); err != nil {
+			t.Fatalf("InsertAttempt() unexpected error: %v", err)
+		}
+		question.AttemptCount++
+	}
+	if err := service.db.UpdateQuestion(question); err != nil {
+		t.Fatalf("UpdateQuestion() unexpected error: %v", err)
+	}
+
+	if leeches, err := service.Leeches(); err != nil || len(leeches) != 1 {
+		t.Fatalf("Leeches() = %+v, %v, want question 1", leeches, err)
+	}
+	if question, _ := service.Question(1); question.HasTag(types.LeechTag) {
+		t.Errorf("Leeches() tagged question 1, want it to leave the database alone")
+	}
+
+	migrated, err := service.Migrate()
+	if err != nil || migrated.TaggedLeeches != 1 {
+		t.Fatalf("Migrate() = %+v, %v, want 1 leech tagged", migrated, err)
+	}
+	if question, _ := service.Question(1); !question.HasTag(types.LeechTag) {
+		t.Errorf("Migrate() didn't tag question 1 as a leech")
+	}
+}
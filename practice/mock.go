@@ -0,0 +1,181 @@
+package practice
+
+import (
+	"dsacli/types"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ErrNoMockQuestions is returned when no question is unseen or due for a mock interview
+var ErrNoMockQuestions = errors.New("no unseen or due questions match the mock interview")
+
+// MockOptions describes a mock interview
+type MockOptions struct {
+	Duration     int      // minutes allowed for the whole session
+	Count        int      // number of problems
+	Difficulties []string // all difficulties when empty
+}
+
+// Validate checks that the options describe a possible session
+func (o MockOptions) Validate() error {
+	if o.Duration <= 0 {
+		return fmt.Errorf("duration must be > 0 minutes")
+	}
+	if o.Count <= 0 {
+		return fmt.Errorf("count must be > 0")
+	}
+	for _, d := range o.Difficulties {
+		if !types.IsValidDifficulty(d) {
+			return fmt.Errorf("invalid difficulty %q, expected one of %s", d, strings.Join(types.Difficulties, ", "))
+		}
+	}
+	return nil
+}
+
+// Mock is a running mock interview
+type Mock struct {
+	Session   types.MockSession
+	Questions []types.Question
+	Deadline  time.Time
+}
+
+// MockResult is how a problem of a mock interview went. Hints aren't allowed.
+type MockResult struct {
+	Solved     bool
+	Elapsed    time.Duration
+	Optimality int
+	Bugs       int
+}
+
+// MockProblem is a problem of a finished mock interview, Attempt is nil when time ran
+// out before it was started
+type MockProblem struct {
+	Question types.Question `json:"question"`
+	Attempt  *types.Attempt `json:"attempt,omitempty"`
+}
+
+// MockSummary is the report of a finished mock interview
+type MockSummary struct {
+	Session  types.MockSession `json:"session"`
+	Problems []MockProblem     `json:"problems"`
+}
+
+// StartMock picks the problems of a mock interview among the unseen and due questions
+// and starts the session clock
+func (s *Service) StartMock(opts MockOptions) (Mock, error) {
+	if err := opts.Validate(); err != nil {
+		return Mock{}, err
+	}
+
+	questions, err := s.mockQuestions(opts)
+	if err != nil {
+		return Mock{}, err
+	}
+
+	now := s.clock.Now()
+	session := types.MockSession{
+		Date:            s.Date(),
+		StartedAt:       now,
+		DurationMinutes: opts.Duration,
+		Difficulties:    opts.Difficulties,
+	}
+	if err := s.db.InsertMockSession(&session); err != nil {
+		return Mock{}, fmt.Errorf("saving mock session: %w", err)
+	}
+
+	return Mock{
+		Session:   session,
+		Questions: questions,
+		Deadline:  now.Add(time.Duration(opts.Duration) * time.Minute),
+	}, nil
+}
+
+// mockQuestions returns up to opts.Count random questions of the given difficulties that
+// were never attempted or are due for review
+func (s *Service) mockQuestions(opts MockOptions) ([]types.Question, error) {
+	questions, err := s.db.GetActiveQuestions()
+	if err != nil {
+		return nil, fmt.Errorf("loading questions: %w", err)
+	}
+	due, err := s.Due()
+	if err != nil {
+		return nil, fmt.Errorf("loading due questions: %w", err)
+	}
+	isDue := make(map[uint]bool)
+	for _, q := range due {
+		isDue[q.ID] = true
+	}
+	difficulties := make(map[string]bool)
+	for _, d := range opts.Difficulties {
+		difficulties[d] = true
+	}
+
+	var pool []types.Question
+	for _, q := range questions {
+		if len(difficulties) > 0 && !difficulties[q.Difficulty] {
+			continue
+		}
+		if !q.Attempted || isDue[q.ID] {
+			pool = append(pool, q)
+		}
+	}
+	if len(pool) == 0 {
+		return nil, ErrNoMockQuestions
+	}
+
+	s.rand.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+	return pool[:min(opts.Count, len(pool))], nil
+}
+
+// RecordMockAttempt records how a problem of the mock interview went through the same spaced
+// repetition path as any other attempt. A problem finished after the deadline counts as unsolved.
+func (s *Service) RecordMockAttempt(mock Mock, questionID uint, result MockResult) (AttemptResult, error) {
+	feedback := Feedback{
+		HintsNeeded:     0,
+		TimeTaken:       UnsolvedTimeValue,
+		OptimalSolution: MinRating,
+		AnyBugs:         MinRating,
+	}
+	if result.Solved && !s.clock.Now().After(mock.Deadline) {
+		feedback.TimeTaken = min(max(int(math.Ceil(result.Elapsed.Minutes())), 1), MaxMinutes)
+		feedback.OptimalSolution = result.Optimality
+		feedback.AnyBugs = result.Bugs
+	}
+	return s.recordAttempt(questionID, feedback, &mock.Session.ID)
+}
+
+// FinishMock ends the mock interview and scores it with the average p-score of its
+// problems, counting the ones that were never started as 0
+func (s *Service) FinishMock(mock Mock) (MockSummary, error) {
+	attempts, err := s.db.GetSessionAttempts(mock.Session.ID)
+	if err != nil {
+		return MockSummary{}, fmt.Errorf("loading session attempts: %w", err)
+	}
+	byQuestion := make(map[uint]types.Attempt)
+	for _, a := range attempts {
+		byQuestion[a.QuestionID] = a
+	}
+
+	summary := MockSummary{Session: mock.Session}
+	total := 0.0
+	for _, q := range mock.Questions {
+		problem := MockProblem{Question: q}
+		if a, ok := byQuestion[q.ID]; ok {
+			problem.Attempt = &a
+			total += a.PScore
+		}
+		summary.Problems = append(summary.Problems, problem)
+	}
+
+	summary.Session.EndedAt = s.clock.Now()
+	if len(mock.Questions) > 0 {
+		summary.Session.Score = total / float64(len(mock.Questions))
+	}
+	if err := s.db.UpdateMockSession(summary.Session); err != nil {
+		return MockSummary{}, fmt.Errorf("saving mock session: %w", err)
+	}
+	return summary, nil
+}
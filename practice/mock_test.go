@@ -0,0 +1,92 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"testing"
+	"time"
+)
+
+func TestMockOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    MockOptions
+		wantErr bool
+	}{
+		{name: "Valid", opts: MockOptions{Duration: 45, Count: 2, Difficulties: []string{"medium", "hard"}}},
+		{name: "All difficulties", opts: MockOptions{Duration: 45, Count: 2}},
+		{name: "No time", opts: MockOptions{Duration: 0, Count: 2}, wantErr: true},
+		{name: "No problems", opts: MockOptions{Duration: 45, Count: 0}, wantErr: true},
+		{name: "Unknown difficulty", opts: MockOptions{Duration: 45, Count: 2, Difficulties: []string{"extreme"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMockSession(t *testing.T) {
+	clk := clock.NewManual(time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local))
+	service := newTestService(t, clk, 1, 3)
+
+	// A question reviewed recently is neither unseen nor due
+	perfect := Feedback{HintsNeeded: 0, TimeTaken: 10, OptimalSolution: MaxRating, AnyBugs: MaxRating}
+	if _, err := service.RecordAttempt(1, perfect); err != nil {
+		t.Fatalf("RecordAttempt() unexpected error: %v", err)
+	}
+
+	mock, err := service.StartMock(MockOptions{Duration: 45, Count: 3})
+	if err != nil {
+		t.Fatalf("StartMock() unexpected error: %v", err)
+	}
+	if len(mock.Questions) != 2 {
+		t.Fatalf("Expected the 2 unseen questions, got %d", len(mock.Questions))
+	}
+	for _, q := range mock.Questions {
+		if q.ID == 1 {
+			t.Errorf("Expected the recently reviewed question to be left out")
+		}
+	}
+
+	first, second := mock.Questions[0], mock.Questions[1]
+	clk.Advance(20 * time.Minute)
+	result, err := service.RecordMockAttempt(mock, first.ID, MockResult{Solved: true, Elapsed: 19*time.Minute + 10*time.Second, Optimality: 5, Bugs: 5})
+	if err != nil {
+		t.Fatalf("RecordMockAttempt() unexpected error: %v", err)
+	}
+	if result.Attempt.TimeTaken != 20 || result.Attempt.HintsUsed != 0 || !result.Question.Attempted {
+		t.Errorf("Expected a 20 minute attempt without hints, got %+v", result.Attempt)
+	}
+
+	clk.Advance(30 * time.Minute)
+	result, err = service.RecordMockAttempt(mock, second.ID, MockResult{Solved: true, Elapsed: 30 * time.Minute, Optimality: 5, Bugs: 5})
+	if err != nil {
+		t.Fatalf("RecordMockAttempt() unexpected error: %v", err)
+	}
+	if result.Attempt.TimeTaken != UnsolvedTimeValue {
+		t.Errorf("Expected a problem finished after the deadline to be unsolved, got %d minutes", result.Attempt.TimeTaken)
+	}
+
+	summary, err := service.FinishMock(mock)
+	if err != nil {
+		t.Fatalf("FinishMock() unexpected error: %v", err)
+	}
+	if len(summary.Problems) != 2 || summary.Problems[0].Attempt == nil || summary.Problems[1].Attempt == nil {
+		t.Fatalf("Expected both problems in the summary, got %+v", summary.Problems)
+	}
+	expected := (summary.Problems[0].Attempt.PScore + summary.Problems[1].Attempt.PScore) / 2
+	if summary.Session.Score != expected {
+		t.Errorf("Expected a score of %.2f, got %.2f", expected, summary.Session.Score)
+	}
+
+	attempts, err := service.db.GetSessionAttempts(mock.Session.ID)
+	if err != nil {
+		t.Fatalf("GetSessionAttempts() unexpected error: %v", err)
+	}
+	if len(attempts) != 2 {
+		t.Errorf("Expected 2 attempts grouped in the session, got %d", len(attempts))
+	}
+}
@@ -0,0 +1,455 @@
+package practice
+
+import (
+	"dsacli/common"
+	"dsacli/config"
+	"dsacli/db"
+	"dsacli/types"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+const (
+	questionsPerDay = 2
+
+	// Phases of the difficulty progression, in the order they are unlocked
+	EasyPhase    = "easy"
+	MediumPhase  = "medium"
+	HardPhase    = "hard"
+	MasteryPhase = "mastery"
+)
+
+// Plan is the list of questions to practice today
+type Plan struct {
+	Questions []types.TodayQuestionWithStatus `json:"questions"`
+	// Phase is the progression phase the questions were picked for. It is only set
+	// when the plan was generated by this call.
+	Phase string `json:"phase,omitempty"`
+	// Generated is true when the questions were picked by this call rather than loaded
+	Generated bool `json:"generated"`
+	// Extra is true when the questions were generated on top of an already completed plan
+	Extra bool `json:"extra"`
+	// Carried is how many of the questions were rolled over from missed days
+	Carried int `json:"carried"`
+	// PausedUntil is set when practice is paused and no questions are planned
+	PausedUntil string `json:"paused_until,omitempty"`
+	// BudgetMinutes is the practice time the generated plan was filled up to, 0 without a budget
+	BudgetMinutes int `json:"budget_minutes,omitempty"`
+	// PlannedMinutes is the expected time to solve the generated questions, carried ones included
+	PlannedMinutes int `json:"planned_minutes,omitempty"`
+	// InterviewIn is the number of days left until the interview goal, when one is set
+	InterviewIn int `json:"interview_in,omitempty"`
+	// Trace tells how the generated questions were picked, it's only set when Generated is
+	Trace *Trace `json:"trace,omitempty"`
+}
+
+// Completed returns the number of completed questions in the plan
+func (p Plan) Completed() int {
+	completed := 0
+	for _, q := range p.Questions {
+		if q.Completed {
+			completed++
+		}
+	}
+	return completed
+}
+
+// AllCompleted reports whether the plan has questions and all of them are completed
+func (p Plan) AllCompleted() bool {
+	return len(p.Questions) > 0 && p.Completed() == len(p.Questions)
+}
+
+// Today returns today's questions with their completion status without generating a plan
+func (s *Service) Today() (Plan, error) {
+	questions, err := s.db.GetTodayQuestionsWithStatus(s.Date())
+	if err != nil {
+		return Plan{}, fmt.Errorf("loading today's questions: %w", err)
+	}
+	return Plan{Questions: questions}, nil
+}
+
+// OpenQuestions returns the questions that can still be completed: the uncompleted ones
+// from today's plan and from the previous day's plan, so that a session running past the
+// day boundary can still be finished
+func (s *Service) OpenQuestions() ([]types.TodayQuestionWithStatus, error) {
+	var open []types.TodayQuestionWithStatus
+	seen := make(map[uint]bool)
+	for _, date := range []string{s.Date(), s.previousDate()} {
+		questions, err := s.db.GetTodayQuestionsWithStatus(date)
+		if err != nil {
+			return nil, fmt.Errorf("loading the plan of %s: %w", date, err)
+		}
+		for _, q := range questions {
+			if !q.Completed && !seen[q.Question.ID] {
+				seen[q.Question.ID] = true
+				open = append(open, q)
+			}
+		}
+	}
+	return open, nil
+}
+
+// PlanToday returns today's plan, generating and saving one first if none exists yet.
+// A new plan starts with unfinished questions carried over from missed days. When more
+// is set and today's plan is already completed, extra questions are generated that don't
+// repeat the ones already done today. With a daily budget set the plan is filled up to it
+// using the expected solve time of each question. An interview goal raises the intake of new
+// questions and turns the final week into reviews of weak questions. New questions whose
+// prerequisites weren't attempted yet are held back and the ones asked by the focus companies
+// are picked first. Nothing is planned while practice is paused.
+func (s *Service) PlanToday(more bool) (Plan, error) {
+	s.planning.Lock()
+	defer s.planning.Unlock()
+
+	pause, paused, err := s.ActivePause()
+	if err != nil {
+		return Plan{}, err
+	}
+	if paused {
+		return Plan{PausedUntil: pause.Until}, nil
+	}
+
+	existing, err := s.Today()
+	if err != nil {
+		return Plan{}, err
+	}
+	if len(existing.Questions) > 0 && (!more || !existing.AllCompleted()) {
+		return existing, nil
+	}
+
+	ignore := make([]uint, 0, len(existing.Questions))
+	for _, q := range existing.Questions {
+		ignore = append(ignore, q.Question.ID)
+	}
+
+	date := s.Date()
+	plan := Plan{Generated: true, Extra: len(existing.Questions) > 0}
+	goal, hasGoal, err := s.ActiveGoal()
+	if err != nil {
+		return Plan{}, err
+	}
+	if hasGoal {
+		if plan.InterviewIn, err = s.daysLeft(goal); err != nil {
+			return Plan{}, err
+		}
+	}
+	if !plan.Extra {
+		finalWeek := hasGoal && plan.InterviewIn <= FinalWeekDays
+		carried, err := s.carryOver(date, ignore, finalWeek)
+		if err != nil {
+			return Plan{}, err
+		}
+		plan.Questions = carried
+		plan.Carried = len(carried)
+		for _, q := range carried {
+			ignore = append(ignore, q.Question.ID)
+		}
+	}
+
+	// New questions wait until their prerequisites were attempted
+	blocked, err := s.blocked()
+	if err != nil {
+		return Plan{}, err
+	}
+	ignore = append(ignore, blocked...)
+
+	var questions []types.Question
+	var phase string
+	// Every plan picks with its own source, seeded from the shared one, so the seed in the
+	// trace reproduces this plan even in a long-running server
+	seed := s.seed
+	s.seed = s.rand.Int63()
+	rng := common.NewRand(seed)
+	trace := newTrace(seed)
+	trace.Blocked = len(blocked)
+	if budget := s.Budget(); budget > 0 {
+		plan.BudgetMinutes = budget
+		questions, phase, plan.PlannedMinutes, err = s.budgetPlan(rng, budget, plan.Questions, ignore, trace)
+	} else {
+		questions, phase, err = generateTodayQuestions(s.db, rng, s.settings, s.clock.Now(), ignore, trace)
+	}
+	if err != nil {
+		return Plan{}, fmt.Errorf("generating today's questions: %w", err)
+	}
+
+	if hasGoal {
+		questions, phase, err = s.applyGoal(rng, goal, questions, phase, ignore, trace)
+		if err != nil {
+			return Plan{}, fmt.Errorf("adjusting today's questions to the interview goal: %w", err)
+		}
+		if plan.BudgetMinutes > 0 {
+			if plan.PlannedMinutes, err = s.plannedMinutes(plan.Questions, questions); err != nil {
+				return Plan{}, err
+			}
+		}
+	}
+	plan.Phase = phase
+	trace.traceCompanies(questions, s.settings.Focus())
+	trace.finish(phase, questions)
+	plan.Trace = trace
+	if len(questions) == 0 {
+		return plan, nil
+	}
+
+	if err := s.db.InsertTodayQuestions(date, questions, trace.Picks); err != nil {
+		return Plan{}, fmt.Errorf("saving today's questions: %w", err)
+	}
+
+	for _, q := range questions {
+		entry := types.TodayQuestionWithStatus{Question: q, Date: date}
+		if pick, ok := trace.Picks[q.ID]; ok {
+			entry.Pick = &pick
+		}
+		plan.Questions = append(plan.Questions, entry)
+	}
+	return plan, nil
+}
+
+// generateTodayQuestions generates new questions for the phase the progression gates are in
+// and returns them along with that phase. Why each was picked is recorded in the trace.
+func generateTodayQuestions(database db.Database, rng *rand.Rand, settings config.Settings, now time.Time, questionsToIgnore []uint, trace *Trace) ([]types.Question, string, error) {
+	tiers := make(map[string][]types.Question)
+	for _, difficulty := range types.Difficulties {
+		questions, err := database.GetQuestionsByDifficulty(difficulty)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to load %s questions: %w", difficulty, err)
+		}
+		tiers[difficulty] = questions
+	}
+
+	// The gates look at whole tiers, the questions already planned today are only left out of the picks
+	phase := gatedPhase(tiers, settings.Gates)
+	var allQuestions []types.Question
+	for _, difficulty := range types.Difficulties {
+		tiers[difficulty] = filterOutQuestions(tiers[difficulty], questionsToIgnore)
+		allQuestions = append(allQuestions, tiers[difficulty]...)
+	}
+
+	reviews := allQuestions
+	if phase != MasteryPhase {
+		needed := 1
+		if phase == EasyPhase {
+			needed = questionsPerDay
+		}
+		pool := preferCompanies(focusPool(rng, tiers, phase, settings.GateLeak), settings.Focus(), needed)
+		switch {
+		case len(filterUnattemptedQuestions(pool)) == 0:
+			// The gate is closed with nothing left to attempt, so the plan is filled with the
+			// tier's weak and due reviews until it opens
+			reviews = pool
+		case phase == EasyPhase:
+			questions := generateEasyPhaseQuestions(rng, pool)
+			for _, q := range questions {
+				trace.pickFocus(q, pool)
+				pool = filterOutQuestion(pool, q.ID)
+			}
+			trace.leaks(questions, phase)
+			return questions, phase, nil
+		case phase == MediumPhase:
+			candidates := append(append([]types.Question(nil), tiers[EasyPhase]...), pool...)
+			questions := generateMediumPhaseQuestions(rng, pool, tiers[EasyPhase])
+			traceFocusAndReview(trace, questions, pool, candidates)
+			trace.leaks(questions, phase)
+			return questions, phase, nil
+		default:
+			questions := generateHardPhaseQuestions(rng, pool, allQuestions)
+			traceFocusAndReview(trace, questions, pool, allQuestions)
+			return questions, phase, nil
+		}
+	}
+
+	attempts, err := database.GetAttempts()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load attempts: %w", err)
+	}
+	failures := failureStreaks(attempts)
+	questions := generateMasteryPhaseQuestions(reviews, failures, now)
+	trace.pickRanked(questions, rankReviews(reviews, failures, now), failures, now)
+	return questions, phase, nil
+}
+
+// traceFocusAndReview records the picks of the medium and hard phases: a focus question from
+// the pool followed by the attempted candidate with the highest p-score
+func traceFocusAndReview(trace *Trace, questions, pool, candidates []types.Question) {
+	if len(questions) == 0 {
+		return
+	}
+	trace.pickFocus(questions[0], pool)
+	if len(questions) > 1 {
+		trace.pickHighestPScore(questions[1], buildAttemptedPool(candidates, questions[0].ID))
+	}
+}
+
+// focusPool returns the questions to pick the new ones of a phase from: the phase's tier along
+// with the questions of lower tiers that were never attempted. With a gate leak, the next tier's
+// unattempted questions are used instead on that share of the days.
+func focusPool(rng *rand.Rand, tiers map[string][]types.Question, phase string, leak float64) []types.Question {
+	if next := filterUnattemptedQuestions(tiers[nextTier(phase)]); len(next) > 0 && leak > 0 && rng.Float64() < leak {
+		return next
+	}
+
+	pool := append([]types.Question(nil), tiers[phase]...)
+	for _, difficulty := range types.Difficulties {
+		if difficulty == phase {
+			break
+		}
+		pool = append(pool, filterUnattemptedQuestions(tiers[difficulty])...)
+	}
+	return pool
+}
+
+// generateEasyPhaseQuestions generates questions for the easy phase
+func generateEasyPhaseQuestions(rng *rand.Rand, easyQuestions []types.Question) []types.Question {
+	var questions []types.Question
+
+	// Get first question
+	if q1, hasQuestion := getFocusQuestion(rng, easyQuestions); hasQuestion {
+		questions = append(questions, q1)
+
+		// Get second question from remaining easy questions
+		remaining := filterOutQuestion(easyQuestions, q1.ID)
+		if q2, hasQuestion := getFocusQuestion(rng, remaining); hasQuestion {
+			questions = append(questions, q2)
+		}
+	}
+
+	return questions
+}
+
+// generateMediumPhaseQuestions generates questions for the medium phase with smart review
+func generateMediumPhaseQuestions(rng *rand.Rand, mediumQuestions, easyQuestions []types.Question) []types.Question {
+	var questions []types.Question
+
+	// Get focus question from medium difficulty
+	if qFocus, hasQuestion := getFocusQuestion(rng, mediumQuestions); hasQuestion {
+		questions = append(questions, qFocus)
+
+		// Get review question from attempted easy/medium questions
+		attemptedPool := buildAttemptedPool(append(easyQuestions, mediumQuestions...), qFocus.ID)
+		if qReview, hasQuestion := getHighestSRQuestion(attemptedPool); hasQuestion {
+			questions = append(questions, qReview)
+		}
+	}
+
+	return questions
+}
+
+// generateHardPhaseQuestions generates questions for the hard phase with smart review
+func generateHardPhaseQuestions(rng *rand.Rand, hardQuestions, allQuestions []types.Question) []types.Question {
+	var questions []types.Question
+
+	// Get focus question from hard difficulty
+	if qFocus, hasQuestion := getFocusQuestion(rng, hardQuestions); hasQuestion {
+		questions = append(questions, qFocus)
+
+		// Get review question from all attempted questions
+		attemptedPool := buildAttemptedPool(allQuestions, qFocus.ID)
+		if qReview, hasQuestion := getHighestSRQuestion(attemptedPool); hasQuestion {
+			questions = append(questions, qReview)
+		}
+	}
+
+	return questions
+}
+
+// generateMasteryPhaseQuestions picks the reviews with the highest priority, favouring weak
+// and overdue questions, see rankReviews
+func generateMasteryPhaseQuestions(allQuestions []types.Question, failures map[uint]int, now time.Time) []types.Question {
+	reviews := rankReviews(allQuestions, failures, now)
+	return reviews[:min(questionsPerDay, len(reviews))]
+}
+
+func filterOutQuestion(questions []types.Question, excludeID uint) []types.Question {
+	var filtered []types.Question
+	for _, q := range questions {
+		if q.ID != excludeID {
+			filtered = append(filtered, q)
+		}
+	}
+	return filtered
+}
+
+// filterOutQuestions removes every question whose ID is in excludeIDs
+func filterOutQuestions(questions []types.Question, excludeIDs []uint) []types.Question {
+	if len(excludeIDs) == 0 {
+		return questions
+	}
+	for _, id := range excludeIDs {
+		questions = filterOutQuestion(questions, id)
+	}
+	return questions
+}
+
+func buildAttemptedPool(questions []types.Question, excludeID uint) []types.Question {
+	var attemptedPool []types.Question
+	for _, q := range questions {
+		if q.Attempted && q.ID != excludeID {
+			attemptedPool = append(attemptedPool, q)
+		}
+	}
+	return attemptedPool
+}
+
+func getHighestSRQuestion(questions []types.Question) (types.Question, bool) {
+	if len(questions) == 0 {
+		return types.Question{}, false
+	}
+
+	maxSR := questions[0]
+	for _, q := range questions {
+		if q.LastPScore > maxSR.LastPScore {
+			maxSR = q
+		}
+	}
+
+	return maxSR, true
+}
+
+func allAttempted(questions []types.Question) bool {
+	if len(questions) == 0 {
+		return true
+	}
+
+	for _, q := range questions {
+		if !q.Attempted {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getFocusQuestion returns the best question to focus on from the given pool
+// It prioritizes unattempted questions first, then the unmastered ones with highest SR score
+func getFocusQuestion(rng *rand.Rand, pool []types.Question) (types.Question, bool) {
+	if len(pool) == 0 {
+		return types.Question{}, false
+	}
+
+	// First, try to get an unattempted question
+	unattempted := filterUnattemptedQuestions(pool)
+	if len(unattempted) > 0 {
+		return unattempted[rng.Intn(len(unattempted))], true
+	}
+
+	// If all are attempted, get the unmastered one with highest SR score
+	var unmastered []types.Question
+	for _, q := range pool {
+		if !q.Mastered {
+			unmastered = append(unmastered, q)
+		}
+	}
+	return getHighestSRQuestion(unmastered)
+}
+
+// filterUnattemptedQuestions returns only the questions that haven't been attempted
+func filterUnattemptedQuestions(questions []types.Question) []types.Question {
+	var unattempted []types.Question
+	for _, q := range questions {
+		if !q.Attempted {
+			unattempted = append(unattempted, q)
+		}
+	}
+	return unattempted
+}
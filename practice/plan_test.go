@@ -0,0 +1,1041 @@
+package practice
+
+import (
+	"dsacli/common"
+	"dsacli/config"
+	"dsacli/db/dbtest"
+	"dsacli/types"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	testRand   = common.NewRand(1)
+	testConfig = config.NewConfig("test.db")
+)
+
+// Helper function to create test questions
+func createTestQuestion(id uint, name, difficulty string, attempted bool, pScore float64) types.Question {
+	return types.Question{
+		ID:           id,
+		Name:         name,
+		URL:          "https://example.com/" + name,
+		Difficulty:   difficulty,
+		LastReviewed: nil, // Set to nil to avoid time comparison issues
+		LastPScore:   pScore,
+		Attempted:    attempted,
+	}
+}
+
+// createMasteredQuestion creates an attempted question that passed the mastery criterion
+func createMasteredQuestion(id uint, name, difficulty string, pScore float64) types.Question {
+	q := createTestQuestion(id, name, difficulty, true, pScore)
+	q.Mastered = true
+	q.AttemptCount = 1
+	return q
+}
+
+// dueQuestion makes the question due for review a day ago
+func dueQuestion(q types.Question) types.Question {
+	reviewed := time.Now().AddDate(0, 0, -2)
+	q.LastReviewed = &reviewed
+	q.ReviewInterval = 1
+	return q
+}
+
+// Helper function to compare question slices ignoring time fields
+func questionsEqual(a, b []types.Question) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !questionEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Helper function to compare individual questions ignoring time fields
+func questionEqual(a, b types.Question) bool {
+	return a.ID == b.ID &&
+		a.Name == b.Name &&
+		a.URL == b.URL &&
+		a.Difficulty == b.Difficulty &&
+		a.LastPScore == b.LastPScore &&
+		a.Attempted == b.Attempted
+}
+
+func questionOneOf(q types.Question, questions ...types.Question) bool {
+	for _, candidate := range questions {
+		if questionEqual(q, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAllAttempted(t *testing.T) {
+	tests := []struct {
+		name      string
+		questions []types.Question
+		expected  bool
+	}{
+		{
+			name:      "Empty slice",
+			questions: []types.Question{},
+			expected:  true,
+		},
+		{
+			name: "All attempted",
+			questions: []types.Question{
+				createTestQuestion(1, "q1", "easy", true, 0),
+				createTestQuestion(2, "q2", "easy", true, 0),
+			},
+			expected: true,
+		},
+		{
+			name: "Some attempted",
+			questions: []types.Question{
+				createTestQuestion(1, "q1", "easy", true, 0),
+				createTestQuestion(2, "q2", "easy", false, 0),
+			},
+			expected: false,
+		},
+		{
+			name: "None attempted",
+			questions: []types.Question{
+				createTestQuestion(1, "q1", "easy", false, 0),
+				createTestQuestion(2, "q2", "easy", false, 0),
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := allAttempted(tt.questions)
+			if result != tt.expected {
+				t.Errorf("allAttempted() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilterUnattemptedQuestions(t *testing.T) {
+	tests := []struct {
+		name      string
+		questions []types.Question
+		expected  []types.Question
+	}{
+		{
+			name:      "Empty slice",
+			questions: []types.Question{},
+			expected:  []types.Question{},
+		},
+		{
+			name: "All attempted",
+			questions: []types.Question{
+				createTestQuestion(1, "q1", "easy", true, 0),
+				createTestQuestion(2, "q2", "easy", true, 0),
+			},
+			expected: []types.Question{},
+		},
+		{
+			name: "Some unattempted",
+			questions: []types.Question{
+				createTestQuestion(1, "q1", "easy", true, 0),
+				createTestQuestion(2, "q2", "easy", false, 0),
+				createTestQuestion(3, "q3", "easy", false, 0),
+			},
+			expected: []types.Question{
+				createTestQuestion(2, "q2", "easy", false, 0),
+				createTestQuestion(3, "q3", "easy", false, 0),
+			},
+		},
+		{
+			name: "All unattempted",
+			questions: []types.Question{
+				createTestQuestion(1, "q1", "easy", false, 0),
+				createTestQuestion(2, "q2", "easy", false, 0),
+			},
+			expected: []types.Question{
+				createTestQuestion(1, "q1", "easy", false, 0),
+				createTestQuestion(2, "q2", "easy", false, 0),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := filterUnattemptedQuestions(tt.questions)
+			if !questionsEqual(result, tt.expected) {
+				t.Errorf("filterUnattemptedQuestions() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilterOutQuestion(t *testing.T) {
+	tests := []struct {
+		name      string
+		questions []types.Question
+		excludeID uint
+		expected  []types.Question
+	}{
+		{
+			name:      "Empty slice",
+			questions: []types.Question{},
+			excludeID: 1,
+			expected:  []types.Question{},
+		},
+		{
+			name: "Exclude existing question",
+			questions: []types.Question{
+				createTestQuestion(1, "q1", "easy", false, 0),
+				createTestQuestion(2, "q2", "easy", false, 0),
+				createTestQuestion(3, "q3", "easy", false, 0),
+			},
+			excludeID: 2,
+			expected: []types.Question{
+				createTestQuestion(1, "q1", "easy", false, 0),
+				createTestQuestion(3, "q3", "easy", false, 0),
+			},
+		},
+		{
+			name: "Exclude non-existing question",
+			questions: []types.Question{
+				createTestQuestion(1, "q1", "easy", false, 0),
+				createTestQuestion(2, "q2", "easy", false, 0),
+			},
+			excludeID: 999,
+			expected: []types.Question{
+				createTestQuestion(1, "q1", "easy", false, 0),
+				createTestQuestion(2, "q2", "easy", false, 0),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := filterOutQuestion(tt.questions, tt.excludeID)
+			if !questionsEqual(result, tt.expected) {
+				t.Errorf("filterOutQuestion() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildAttemptedPool(t *testing.T) {
+	tests := []struct {
+		name      string
+		questions []types.Question
+		excludeID uint
+		expected  []types.Question
+	}{
+		{
+			name:      "Empty slice",
+			questions: []types.Question{},
+			excludeID: 1,
+			expected:  []types.Question{},
+		},
+		{
+			name: "Mixed attempted and unattempted",
+			questions: []types.Question{
+				createTestQuestion(1, "q1", "easy", true, 50),
+				createTestQuestion(2, "q2", "easy", false, 0),
+				createTestQuestion(3, "q3", "easy", true, 30),
+				createTestQuestion(4, "q4", "easy", true, 70),
+			},
+			excludeID: 2,
+			expected: []types.Question{
+				createTestQuestion(1, "q1", "easy", true, 50),
+				createTestQuestion(3, "q3", "easy", true, 30),
+				createTestQuestion(4, "q4", "easy", true, 70),
+			},
+		},
+		{
+			name: "Exclude attempted question",
+			questions: []types.Question{
+				createTestQuestion(1, "q1", "easy", true, 50),
+				createTestQuestion(2, "q2", "easy", true, 30),
+				createTestQuestion(3, "q3", "easy", true, 70),
+			},
+			excludeID: 2,
+			expected: []types.Question{
+				createTestQuestion(1, "q1", "easy", true, 50),
+				createTestQuestion(3, "q3", "easy", true, 70),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := buildAttemptedPool(tt.questions, tt.excludeID)
+			if !questionsEqual(result, tt.expected) {
+				t.Errorf("buildAttemptedPool() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetHighestSRQuestion(t *testing.T) {
+	tests := []struct {
+		name         string
+		questions    []types.Question
+		expectedQ    types.Question
+		expectedBool bool
+	}{
+		{
+			name:         "Empty slice",
+			questions:    []types.Question{},
+			expectedQ:    types.Question{},
+			expectedBool: false,
+		},
+		{
+			name: "Single question",
+			questions: []types.Question{
+				createTestQuestion(1, "q1", "easy", true, 50),
+			},
+			expectedQ:    createTestQuestion(1, "q1", "easy", true, 50),
+			expectedBool: true,
+		},
+		{
+			name: "Multiple questions",
+			questions: []types.Question{
+				createTestQuestion(1, "q1", "easy", true, 30),
+				createTestQuestion(2, "q2", "easy", true, 70),
+				createTestQuestion(3, "q3", "easy", true, 50),
+			},
+			expectedQ:    createTestQuestion(2, "q2", "easy", true, 70),
+			expectedBool: true,
+		},
+		{
+			name: "Questions with same SR score",
+			questions: []types.Question{
+				createTestQuestion(1, "q1", "easy", true, 50),
+				createTestQuestion(2, "q2", "easy", true, 50),
+			},
+			expectedQ:    createTestQuestion(1, "q1", "easy", true, 50), // First one wins
+			expectedBool: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resultQ, resultBool := getHighestSRQuestion(tt.questions)
+			if !questionEqual(resultQ, tt.expectedQ) || resultBool != tt.expectedBool {
+				t.Errorf("getHighestSRQuestion() = (%v, %v), want (%v, %v)", resultQ, resultBool, tt.expectedQ, tt.expectedBool)
+			}
+		})
+	}
+}
+
+func TestGetFocusQuestion(t *testing.T) {
+	tests := []struct {
+		name            string
+		questions       []types.Question
+		expectHighestSR bool
+		expectedBool    bool
+	}{
+		{
+			name:            "Empty slice",
+			questions:       []types.Question{},
+			expectHighestSR: false,
+			expectedBool:    false,
+		},
+		{
+			name: "Only unattempted questions",
+			questions: []types.Question{
+				createTestQuestion(1, "q1", "easy", false, 0),
+				createTestQuestion(2, "q2", "easy", false, 0),
+			},
+			expectHighestSR: false,
+			expectedBool:    true,
+		},
+		{
+			name: "Only attempted questions",
+			questions: []types.Question{
+				createTestQuestion(1, "q1", "easy", true, 30),
+				createTestQuestion(2, "q2", "easy", true, 70),
+			},
+			expectedBool:    true,
+			expectHighestSR: true,
+		},
+		{
+			name: "Only mastered questions",
+			questions: []types.Question{
+				createMasteredQuestion(1, "q1", "easy", 70),
+			},
+			expectedBool: false,
+		},
+		{
+			name: "Mixed attempted and unattempted",
+			questions: []types.Question{
+				createTestQuestion(1, "q1", "easy", true, 70),
+				createTestQuestion(2, "q2", "easy", false, 0),
+				createTestQuestion(3, "q3", "easy", true, 30),
+			},
+			expectedBool:    true,
+			expectHighestSR: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resultQ, resultBool := getFocusQuestion(testRand, tt.questions)
+			if resultBool != tt.expectedBool {
+				t.Errorf("getFocusQuestion(testRand, ) bool = %v, want %v", resultBool, tt.expectedBool)
+			}
+
+			if tt.expectHighestSR {
+				// Expect highest SR question if all are attempted
+				highestQ, _ := getHighestSRQuestion(tt.questions)
+				if !questionEqual(resultQ, highestQ) {
+					t.Errorf("getFocusQuestion(testRand, ) returned %v, want highest SR question %v", resultQ, highestQ)
+				}
+			} else {
+				// Expect any one of unattempted questions
+				unattempted := filterUnattemptedQuestions(tt.questions)
+				if len(unattempted) > 0 && !questionOneOf(resultQ, unattempted...) {
+					t.Errorf("getFocusQuestion(testRand, ) returned %v, want one of unattempted questions %v", resultQ, unattempted)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateEasyPhaseQuestions(t *testing.T) {
+	tests := []struct {
+		name          string
+		easyQuestions []types.Question
+		expectedCount int
+	}{
+		{
+			name:          "Empty questions",
+			easyQuestions: []types.Question{},
+			expectedCount: 0,
+		},
+		{
+			name: "Single question",
+			easyQuestions: []types.Question{
+				createTestQuestion(1, "q1", "easy", false, 0),
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "Multiple questions",
+			easyQuestions: []types.Question{
+				createTestQuestion(1, "q1", "easy", false, 0),
+				createTestQuestion(2, "q2", "easy", false, 0),
+				createTestQuestion(3, "q3", "easy", false, 0),
+			},
+			expectedCount: 2, // questionsPerDay
+		},
+		{
+			name: "All attempted questions",
+			easyQuestions: []types.Question{
+				createTestQuestion(1, "q1", "easy", true, 50),
+				createTestQuestion(2, "q2", "easy", true, 30),
+			},
+			expectedCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := generateEasyPhaseQuestions(testRand, tt.easyQuestions)
+			if len(result) != tt.expectedCount {
+				t.Errorf("generateEasyPhaseQuestions(testRand, ) returned %d questions, want %d", len(result), tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestGenerateMediumPhaseQuestions(t *testing.T) {
+	tests := []struct {
+		name            string
+		mediumQuestions []types.Question
+		easyQuestions   []types.Question
+		expectedCount   int
+	}{
+		{
+			name:            "Empty medium questions",
+			mediumQuestions: []types.Question{},
+			easyQuestions:   []types.Question{},
+			expectedCount:   0,
+		},
+		{
+			name: "Medium question with review pool",
+			mediumQuestions: []types.Question{
+				createTestQuestion(1, "m1", "medium", false, 0),
+			},
+			easyQuestions: []types.Question{
+				createTestQuestion(2, "e1", "easy", true, 50),
+				createTestQuestion(3, "e2", "easy", true, 30),
+			},
+			expectedCount: 2,
+		},
+		{
+			name: "Multiple medium questions with review pool",
+			mediumQuestions: []types.Question{
+				createTestQuestion(1, "m1", "medium", false, 0),
+				createTestQuestion(2, "m2", "medium", false, 0),
+			},
+			easyQuestions: []types.Question{
+				createTestQuestion(3, "e1", "easy", true, 50),
+			},
+			expectedCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := generateMediumPhaseQuestions(testRand, tt.mediumQuestions, tt.easyQuestions)
+			if len(result) != tt.expectedCount {
+				t.Errorf("generateMediumPhaseQuestions(testRand, ) returned %d questions, want %d", len(result), tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestGenerateHardPhaseQuestions(t *testing.T) {
+	tests := []struct {
+		name          string
+		hardQuestions []types.Question
+		allQuestions  []types.Question
+		expectedCount int
+	}{
+		{
+			name:          "Empty hard questions",
+			hardQuestions: []types.Question{},
+			allQuestions:  []types.Question{},
+			expectedCount: 0,
+		},
+		{
+			name: "Hard question with review pool",
+			hardQuestions: []types.Question{
+				createTestQuestion(1, "h1", "hard", false, 0),
+			},
+			allQuestions: []types.Question{
+				createTestQuestion(1, "h1", "hard", false, 0),
+				createTestQuestion(2, "e1", "easy", true, 50),
+				createTestQuestion(3, "m1", "medium", true, 30),
+			},
+			expectedCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := generateHardPhaseQuestions(testRand, tt.hardQuestions, tt.allQuestions)
+			if len(result) != tt.expectedCount {
+				t.Errorf("generateHardPhaseQuestions(testRand, ) returned %d questions, want %d", len(result), tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestGenerateMasteryPhaseQuestions(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.Local)
+	reviewed := func(daysAgo int) *time.Time {
+		at := now.AddDate(0, 0, -daysAgo)
+		return &at
+	}
+	question := func(id uint, pScore float64, daysAgo, interval int, mastered bool) types.Question {
+		return types.Question{
+			ID: id, Name: fmt.Sprintf("q%d", id), Difficulty: "easy", Attempted: true, AttemptCount: 1,
+			LastPScore: pScore, LastReviewed: reviewed(daysAgo), ReviewInterval: interval, EasinessFactor: 2.5, Mastered: mastered,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		questions   []types.Question
+		failures    map[uint]int
+		expectedIDs []uint
+	}{
+		{
+			name:        "Empty questions",
+			questions:   []types.Question{},
+			expectedIDs: []uint{},
+		},
+		{
+			name:        "Weakest questions first",
+			questions:   []types.Question{question(1, 0.9, 1, 6, false), question(2, 0.3, 1, 1, false), question(3, 0.5, 1, 1, false)},
+			expectedIDs: []uint{2, 3},
+		},
+		{
+			name:        "Overdue questions first",
+			questions:   []types.Question{question(1, 0.7, 2, 1, false), question(2, 0.7, 20, 1, false), question(3, 0.7, 1, 6, false)},
+			expectedIDs: []uint{2, 1},
+		},
+		{
+			name:        "Failed recalls first",
+			questions:   []types.Question{question(1, 0.5, 1, 1, false), question(2, 0.5, 1, 1, false)},
+			failures:    map[uint]int{2: 3},
+			expectedIDs: []uint{2, 1},
+		},
+		{
+			name:        "Mastered only when due",
+			questions:   []types.Question{question(1, 0.95, 1, 10, true), question(2, 0.95, 12, 10, true), question(3, 0.9, 1, 6, false)},
+			expectedIDs: []uint{2, 3},
+		},
+		{
+			name:        "Unattempted questions are not reviews",
+			questions:   []types.Question{createTestQuestion(1, "q1", "easy", false, 0), question(2, 0.8, 1, 6, false)},
+			expectedIDs: []uint{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := append([]types.Question(nil), tt.questions...)
+			result := generateMasteryPhaseQuestions(tt.questions, tt.failures, now)
+
+			ids := []uint{}
+			for _, q := range result {
+				ids = append(ids, q.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.expectedIDs) {
+				t.Errorf("generateMasteryPhaseQuestions() = %v, want %v", ids, tt.expectedIDs)
+			}
+			if !questionsEqual(tt.questions, original) {
+				t.Errorf("generateMasteryPhaseQuestions() modified the given questions")
+			}
+		})
+	}
+}
+
+func TestGenerateTodayQuestions(t *testing.T) {
+	tests := []struct {
+		name                   string
+		mockDB                 *dbtest.MockDatabase
+		settings               func(*config.Settings)
+		expectedError          bool
+		expectedQuestionsCount int
+		expectedPhase          string
+		expectedIDs            []uint
+	}{
+		{
+			name: "Error loading easy questions",
+			mockDB: &dbtest.MockDatabase{
+				ShouldReturnError: true,
+				ErrorMessage:      "database error",
+			},
+			expectedError: true,
+		},
+		{
+			name: "Easy phase - unattempted easy questions",
+			mockDB: &dbtest.MockDatabase{
+				QuestionsByDifficulty: map[string][]types.Question{
+					EasyPhase: {
+						createTestQuestion(1, "e1", "easy", false, 0),
+						createTestQuestion(2, "e2", "easy", false, 0),
+					},
+				},
+			},
+			expectedError:          false,
+			expectedQuestionsCount: 2,
+			expectedPhase:          EasyPhase,
+		},
+		{
+			name: "Easy phase - all easy attempted but the gate is closed",
+			mockDB: &dbtest.MockDatabase{
+				QuestionsByDifficulty: map[string][]types.Question{
+					EasyPhase: {
+						createMasteredQuestion(1, "e1", "easy", 0.9),
+						createTestQuestion(2, "e2", "easy", true, 0.4),
+					},
+					MediumPhase: {
+						createTestQuestion(3, "m1", "medium", false, 0),
+					},
+				},
+			},
+			expectedError:          false,
+			expectedQuestionsCount: 1, // the mastered e1 isn't due
+			expectedPhase:          EasyPhase,
+			expectedIDs:            []uint{2},
+		},
+		{
+			name: "Mastery phase - everything attempted with the hard gate closed",
+			mockDB: &dbtest.MockDatabase{
+				QuestionsByDifficulty: map[string][]types.Question{
+					EasyPhase: {
+						createMasteredQuestion(1, "e1", "easy", 0.9),
+					},
+					MediumPhase: {
+						createMasteredQuestion(2, "m1", "medium", 0.9),
+					},
+					HardPhase: {
+						createTestQuestion(3, "h1", "hard", true, 0.3),
+						createTestQuestion(4, "h2", "hard", true, 0.5),
+					},
+				},
+			},
+			expectedError:          false,
+			expectedQuestionsCount: 2,
+			expectedPhase:          MasteryPhase,
+			expectedIDs:            []uint{3, 4},
+		},
+		{
+			name: "Medium phase - gate opened by attempts",
+			mockDB: &dbtest.MockDatabase{
+				QuestionsByDifficulty: map[string][]types.Question{
+					EasyPhase: {
+						{ID: 1, Name: "e1", Difficulty: "easy", Attempted: true, AttemptCount: 3},
+						createTestQuestion(2, "e2", "easy", false, 0),
+					},
+					MediumPhase: {
+						createTestQuestion(3, "m1", "medium", false, 0),
+					},
+				},
+			},
+			settings: func(s *config.Settings) {
+				s.Gates.Easy = config.Gate{MinAttempts: 3}
+			},
+			expectedError:          false,
+			expectedQuestionsCount: 2,
+			expectedPhase:          MediumPhase,
+		},
+		{
+			name: "Medium question leaks into the easy phase",
+			mockDB: &dbtest.MockDatabase{
+				QuestionsByDifficulty: map[string][]types.Question{
+					EasyPhase: {
+						createTestQuestion(1, "e1", "easy", false, 0),
+					},
+					MediumPhase: {
+						createTestQuestion(2, "m1", "medium", false, 0),
+						createTestQuestion(3, "m2", "medium", false, 0),
+					},
+				},
+			},
+			settings: func(s *config.Settings) {
+				s.GateLeak = 1
+			},
+			expectedError:          false,
+			expectedQuestionsCount: 2,
+			expectedPhase:          EasyPhase,
+		},
+		{
+			name: "Medium phase - easy gate open",
+			mockDB: &dbtest.MockDatabase{
+				QuestionsByDifficulty: map[string][]types.Question{
+					EasyPhase: {
+						createMasteredQuestion(1, "e1", "easy", 50),
+					},
+					MediumPhase: {
+						createTestQuestion(2, "m1", "medium", false, 0),
+					},
+				},
+			},
+			expectedError:          false,
+			expectedQuestionsCount: 2,
+			expectedPhase:          MediumPhase,
+		},
+		{
+			name: "Hard phase - easy and medium gates open",
+			mockDB: &dbtest.MockDatabase{
+				QuestionsByDifficulty: map[string][]types.Question{
+					EasyPhase: {
+						createMasteredQuestion(1, "e1", "easy", 50),
+					},
+					MediumPhase: {
+						createMasteredQuestion(2, "m1", "medium", 60),
+					},
+					HardPhase: {
+						createTestQuestion(3, "h1", "hard", false, 0),
+					},
+				},
+				AllQuestions: []types.Question{
+					createMasteredQuestion(1, "e1", "easy", 50),
+					createMasteredQuestion(2, "m1", "medium", 60),
+					createTestQuestion(3, "h1", "hard", false, 0),
+				},
+			},
+			expectedError:          false,
+			expectedQuestionsCount: 2,
+			expectedPhase:          HardPhase,
+		},
+		{
+			name: "Mastery phase - all attempted",
+			mockDB: &dbtest.MockDatabase{
+				QuestionsByDifficulty: map[string][]types.Question{
+					EasyPhase: {
+						dueQuestion(createMasteredQuestion(1, "e1", "easy", 50)),
+					},
+					MediumPhase: {
+						dueQuestion(createMasteredQuestion(2, "m1", "medium", 60)),
+					},
+					HardPhase: {
+						dueQuestion(createMasteredQuestion(3, "h1", "hard", 70)),
+					},
+				},
+				AllQuestions: []types.Question{
+					dueQuestion(createMasteredQuestion(1, "e1", "easy", 50)),
+					dueQuestion(createMasteredQuestion(2, "m1", "medium", 60)),
+					dueQuestion(createMasteredQuestion(3, "h1", "hard", 70)),
+				},
+			},
+			expectedError:          false,
+			expectedQuestionsCount: 2, // questionsPerDay in mastery mode
+			expectedPhase:          MasteryPhase,
+		},
+		{
+			name: "Mastery phase - mastered questions not due",
+			mockDB: &dbtest.MockDatabase{
+				QuestionsByDifficulty: map[string][]types.Question{
+					EasyPhase: {
+						createMasteredQuestion(1, "e1", "easy", 50),
+					},
+				},
+				AllQuestions: []types.Question{
+					createMasteredQuestion(1, "e1", "easy", 50),
+				},
+			},
+			expectedError:          false,
+			expectedQuestionsCount: 0,
+			expectedPhase:          MasteryPhase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := config.DefaultSettings()
+			if tt.settings != nil {
+				tt.settings(&settings)
+			}
+			questions, phase, err := generateTodayQuestions(tt.mockDB, testRand, settings, time.Now(), nil, nil)
+
+			if tt.expectedError && err == nil {
+				t.Errorf("generateTodayQuestions() expected error but got none")
+			}
+			if !tt.expectedError && err != nil {
+				t.Errorf("generateTodayQuestions() unexpected error: %v", err)
+			}
+			if len(questions) != tt.expectedQuestionsCount {
+				t.Errorf("generateTodayQuestions() returned %d questions, want %d", len(questions), tt.expectedQuestionsCount)
+			}
+			if tt.expectedPhase != "" && phase != tt.expectedPhase {
+				t.Errorf("generateTodayQuestions() phase = %q, want %q", phase, tt.expectedPhase)
+			}
+			if tt.expectedIDs != nil && !reflect.DeepEqual(questionIDs(questions), tt.expectedIDs) {
+				t.Errorf("generateTodayQuestions() picked %v, want %v", questionIDs(questions), tt.expectedIDs)
+			}
+		})
+	}
+}
+
+func TestToday(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockDB        *dbtest.MockDatabase
+		expectedError bool
+		expectedCount int
+	}{
+		{
+			name: "Database error",
+			mockDB: &dbtest.MockDatabase{
+				ShouldReturnError: true,
+				ErrorMessage:      "database error",
+			},
+			expectedError: true,
+		},
+		{
+			name: "No today questions",
+			mockDB: &dbtest.MockDatabase{
+				TodayQuestionsWithStatus: []types.TodayQuestionWithStatus{},
+			},
+			expectedError: false,
+			expectedCount: 0,
+		},
+		{
+			name: "Has today questions",
+			mockDB: &dbtest.MockDatabase{
+				TodayQuestionsWithStatus: []types.TodayQuestionWithStatus{
+					{
+						Question:  createTestQuestion(1, "q1", "easy", false, 0),
+						Completed: false,
+					},
+					{
+						Question:  createTestQuestion(2, "q2", "medium", true, 50),
+						Completed: true,
+					},
+				},
+			},
+			expectedError: false,
+			expectedCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := NewService(tt.mockDB, testConfig).Today()
+
+			if tt.expectedError && err == nil {
+				t.Errorf("Today() expected error but got none")
+			}
+			if !tt.expectedError && err != nil {
+				t.Errorf("Today() unexpected error: %v", err)
+			}
+			if len(plan.Questions) != tt.expectedCount {
+				t.Errorf("Today() returned %d questions, want %d", len(plan.Questions), tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestPlanTodayMore(t *testing.T) {
+	mockDB := &dbtest.MockDatabase{
+		TodayQuestionsWithStatus: []types.TodayQuestionWithStatus{
+			{Question: createTestQuestion(1, "e1", "easy", true, 50), Completed: true},
+			{Question: createTestQuestion(2, "e2", "easy", true, 50), Completed: true},
+		},
+		QuestionsByDifficulty: map[string][]types.Question{
+			EasyPhase: {
+				createTestQuestion(1, "e1", "easy", true, 50),
+				createTestQuestion(2, "e2", "easy", true, 50),
+				createTestQuestion(3, "e3", "easy", false, 0),
+			},
+		},
+	}
+	service := NewService(mockDB, testConfig)
+
+	plan, err := service.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	if plan.Generated || !plan.AllCompleted() {
+		t.Errorf("PlanToday(false) should return the completed plan, got %+v", plan)
+	}
+
+	plan, err = service.PlanToday(true)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	if !plan.Generated || !plan.Extra {
+		t.Errorf("PlanToday(true) should generate extra questions, got %+v", plan)
+	}
+	for _, q := range plan.Questions {
+		if q.Question.ID == 1 || q.Question.ID == 2 {
+			t.Errorf("PlanToday(true) repeated question %d done today", q.Question.ID)
+		}
+	}
+	if len(plan.Questions) != 1 || plan.Questions[0].Question.ID != 3 {
+		t.Errorf("PlanToday(true) = %v, want only question 3", plan.Questions)
+	}
+}
+
+func TestEdgeCasesForPhaseGeneration(t *testing.T) {
+	t.Run("Easy phase with single question", func(t *testing.T) {
+		questions := []types.Question{
+			createTestQuestion(1, "q1", "easy", false, 0),
+		}
+		result := generateEasyPhaseQuestions(testRand, questions)
+		if len(result) != 1 {
+			t.Errorf("Expected 1 question, got %d", len(result))
+		}
+	})
+
+	t.Run("Medium phase with no review candidates", func(t *testing.T) {
+		mediumQuestions := []types.Question{
+			createTestQuestion(1, "m1", "medium", false, 0),
+		}
+		easyQuestions := []types.Question{
+			createTestQuestion(2, "e1", "easy", false, 0), // Not attempted, so no review candidates
+		}
+		result := generateMediumPhaseQuestions(testRand, mediumQuestions, easyQuestions)
+		if len(result) != 1 { // Only focus question, no review
+			t.Errorf("Expected 1 question, got %d", len(result))
+		}
+	})
+
+	t.Run("Hard phase with no review candidates", func(t *testing.T) {
+		hardQuestions := []types.Question{
+			createTestQuestion(1, "h1", "hard", false, 0),
+		}
+		allQuestions := []types.Question{
+			createTestQuestion(1, "h1", "hard", false, 0),
+			createTestQuestion(2, "e1", "easy", false, 0), // Not attempted
+		}
+		result := generateHardPhaseQuestions(testRand, hardQuestions, allQuestions)
+		if len(result) != 1 { // Only focus question, no review
+			t.Errorf("Expected 1 question, got %d", len(result))
+		}
+	})
+}
+
+func TestDatabaseErrorScenarios(t *testing.T) {
+	t.Run("Error loading medium questions", func(t *testing.T) {
+		mockDB := &dbtest.MockDatabase{
+			QuestionsByDifficulty: map[string][]types.Question{
+				EasyPhase: {
+					createTestQuestion(1, "e1", "easy", true, 50), // All attempted
+				},
+			},
+		}
+		// Simulate error when loading medium questions
+		originalQuestions := mockDB.QuestionsByDifficulty[MediumPhase]
+		mockDB.QuestionsByDifficulty[MediumPhase] = nil
+		mockDB.ShouldReturnError = true
+		mockDB.ErrorMessage = "medium questions error"
+
+		_, _, err := generateTodayQuestions(mockDB, testRand, testConfig.Settings, time.Now(), nil, nil)
+		if err == nil {
+			t.Error("Expected error when loading medium questions")
+		}
+
+		// Restore
+		mockDB.QuestionsByDifficulty[MediumPhase] = originalQuestions
+		mockDB.ShouldReturnError = false
+	})
+
+	t.Run("Error loading hard questions", func(t *testing.T) {
+		mockDB := &dbtest.MockDatabase{
+			QuestionsByDifficulty: map[string][]types.Question{
+				EasyPhase: {
+					createTestQuestion(1, "e1", "easy", true, 50),
+				},
+				MediumPhase: {
+					createTestQuestion(2, "m1", "medium", true, 60),
+				},
+			},
+		}
+		// Simulate error when loading hard questions
+		mockDB.ShouldReturnError = true
+		mockDB.ErrorMessage = "hard questions error"
+
+		_, _, err := generateTodayQuestions(mockDB, testRand, testConfig.Settings, time.Now(), nil, nil)
+		if err == nil {
+			t.Error("Expected error when loading hard questions")
+		}
+	})
+
+	t.Run("Error loading all questions for hard phase", func(t *testing.T) {
+		mockDB := &dbtest.MockDatabase{
+			QuestionsByDifficulty: map[string][]types.Question{
+				EasyPhase: {
+					createTestQuestion(1, "e1", "easy", true, 50),
+				},
+				MediumPhase: {
+					createTestQuestion(2, "m1", "medium", true, 60),
+				},
+				HardPhase: {
+					createTestQuestion(3, "h1", "hard", false, 0),
+				},
+			},
+			AllQuestions: nil, // This will cause error
+		}
+		// Simulate error when loading all questions
+		mockDB.ShouldReturnError = true
+		mockDB.ErrorMessage = "all questions error"
+
+		_, _, err := generateTodayQuestions(mockDB, testRand, testConfig.Settings, time.Now(), nil, nil)
+		if err == nil {
+			t.Error("Expected error when loading all questions")
+		}
+	})
+}
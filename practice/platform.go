@@ -0,0 +1,21 @@
+package practice
+
+import "dsacli/types"
+
+// schedulable reports whether a question can be planned: it isn't archived or suspended, and
+// the settings don't exclude it for being premium or on an excluded platform. The database
+// applies the same rules to its active questions, this is for questions loaded otherwise.
+func (s *Service) schedulable(q types.Question) bool {
+	if q.Archived || q.Suspended {
+		return false
+	}
+	if s.settings.ExcludePremium && q.Premium {
+		return false
+	}
+	for _, platform := range s.settings.ExcludedPlatforms() {
+		if q.Platform == platform {
+			return false
+		}
+	}
+	return true
+}
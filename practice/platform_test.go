@@ -0,0 +1,101 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/config"
+	"dsacli/types"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSchedulable(t *testing.T) {
+	settings := config.DefaultSettings()
+	settings.ExcludePremium = true
+	settings.ExcludePlatforms = "hackerrank,codeforces"
+	service := &Service{settings: settings}
+
+	tests := []struct {
+		name     string
+		question types.Question
+		want     bool
+	}{
+		{name: "Free LeetCode question", question: types.Question{Platform: types.PlatformLeetCode}, want: true},
+		{name: "Premium question", question: types.Question{Platform: types.PlatformLeetCode, Premium: true}},
+		{name: "Excluded platform", question: types.Question{Platform: types.PlatformCodeforces}},
+		{name: "Archived", question: types.Question{Platform: types.PlatformLeetCode, Archived: true}},
+		{name: "Suspended", question: types.Question{Platform: types.PlatformLeetCode, Suspended: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := service.schedulable(tt.question); got != tt.want {
+				t.Errorf("schedulable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanTodayExcludesPremiumAndPlatforms(t *testing.T) {
+	settings := config.DefaultSettings()
+	settings.ExcludePremium = true
+	settings.ExcludePlatforms = types.PlatformHackerRank
+	service := newTestServiceWithSettings(t, clock.Fixed(time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)), 1, 4, settings)
+	excludeQuestions(t, service)
+
+	plan, err := service.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	for _, id := range planIDs(plan) {
+		if id == 1 || id == 2 {
+			t.Errorf("PlanToday() planned excluded question q%d", id)
+		}
+	}
+	if len(plan.Questions) != 2 {
+		t.Errorf("PlanToday() = %v, want q3 and q4", planIDs(plan))
+	}
+}
+
+func TestDueExcludesPremiumAndPlatforms(t *testing.T) {
+	settings := config.DefaultSettings()
+	settings.ExcludePremium = true
+	settings.ExcludePlatforms = types.PlatformHackerRank
+	clk := clock.NewAdjustable()
+	clk.Set(time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local))
+	service := newTestServiceWithSettings(t, clk, 1, 4, settings)
+
+	for id := uint(1); id <= 4; id++ {
+		if _, err := service.RecordAttempt(id, Feedback{TimeTaken: 10, OptimalSolution: 5, AnyBugs: 5}); err != nil {
+			t.Fatalf("RecordAttempt() unexpected error: %v", err)
+		}
+	}
+	excludeQuestions(t, service)
+	clk.Advance(3 * 24 * time.Hour)
+
+	due, err := service.Due()
+	if err != nil {
+		t.Fatalf("Due() unexpected error: %v", err)
+	}
+	if ids := questionIDs(due); !reflect.DeepEqual(ids, []uint{3, 4}) {
+		t.Errorf("Due() = %v, want q3 and q4", ids)
+	}
+}
+
+// excludeQuestions locks q1 and moves q2 to HackerRank, which leaves q3 and q4
+func excludeQuestions(t *testing.T, service *Service) {
+	t.Helper()
+	for id, update := range map[uint]func(*types.Question){
+		1: func(q *types.Question) { q.Premium = true },
+		2: func(q *types.Question) { q.Platform = types.PlatformHackerRank },
+	} {
+		q, err := service.Question(id)
+		if err != nil {
+			t.Fatalf("Question(%d) unexpected error: %v", id, err)
+		}
+		update(&q)
+		if err := service.db.UpdateQuestion(q); err != nil {
+			t.Fatalf("UpdateQuestion() unexpected error: %v", err)
+		}
+	}
+}
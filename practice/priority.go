@@ -0,0 +1,144 @@
+package practice
+
+import (
+	"dsacli/types"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// recallThreshold is the p-score below which an attempt counts as a failed recall
+	recallThreshold = 0.6
+
+	// defaultEasinessFactor is the easiness factor of a question that was never reviewed
+	defaultEasinessFactor = 2.5
+
+	// Weights of the review priority components
+	pScoreWeight    = 3.0
+	overdueWeight   = 1.0
+	failureWeight   = 1.5
+	easinessWeight  = 2.0
+	maxFailureCount = 3
+)
+
+// Priority is how urgently a question needs reviewing, higher first, along with the reasons
+type Priority struct {
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
+// reviewPriority scores a question on its last p-score, the days it is overdue, its trailing
+// failed recalls and its easiness factor. failures is the number of failed recalls in a row.
+func reviewPriority(question types.Question, failures int, now time.Time) Priority {
+	if !question.Attempted {
+		return Priority{Reasons: []string{fmt.Sprintf("new %s question, never attempted", question.Difficulty)}}
+	}
+
+	var p Priority
+	p.Score += (1 - question.LastPScore) * pScoreWeight
+	if question.LastPScore < recallThreshold {
+		p.Reasons = append(p.Reasons, fmt.Sprintf("low p-score %.2f", question.LastPScore))
+	}
+
+	if overdue := overdueDays(question, now); overdue > 0 {
+		p.Score += math.Log2(1+float64(overdue)) * overdueWeight
+		p.Reasons = append(p.Reasons, fmt.Sprintf("%d day(s) overdue", overdue))
+	} else if isDue(question, now) {
+		p.Reasons = append(p.Reasons, "due today")
+	}
+
+	if failures > 0 {
+		p.Score += float64(min(failures, maxFailureCount)) * failureWeight
+		p.Reasons = append(p.Reasons, fmt.Sprintf("failed the last %d recall(s)", failures))
+	}
+
+	// An unset easiness factor is left alone rather than treated as a very hard question
+	if ef := question.EasinessFactor; ef > 0 && ef < defaultEasinessFactor {
+		p.Score += (defaultEasinessFactor - ef) * easinessWeight
+		p.Reasons = append(p.Reasons, fmt.Sprintf("low easiness factor %.2f", ef))
+	}
+
+	if question.Mastered {
+		p.Reasons = append(p.Reasons, "mastered")
+	}
+	if len(p.Reasons) == 0 {
+		p.Reasons = append(p.Reasons, fmt.Sprintf("p-score %.2f", question.LastPScore))
+	}
+	return p
+}
+
+// isDue reports whether the question's review interval has elapsed
+func isDue(question types.Question, now time.Time) bool {
+	due, ok := question.DueDate()
+	return ok && !now.Before(due)
+}
+
+// overdueDays returns the number of whole days since the question came due
+func overdueDays(question types.Question, now time.Time) int {
+	due, ok := question.DueDate()
+	if !ok || now.Before(due) {
+		return 0
+	}
+	return int(now.Sub(due).Hours() / 24)
+}
+
+// failureStreaks counts the failed recalls in a row at the end of each question's history.
+// The attempts are expected oldest first.
+func failureStreaks(attempts []types.Attempt) map[uint]int {
+	streaks := make(map[uint]int)
+	for _, a := range attempts {
+		if a.PScore < recallThreshold {
+			streaks[a.QuestionID]++
+		} else {
+			streaks[a.QuestionID] = 0
+		}
+	}
+	return streaks
+}
+
+// rankReviews returns the attempted questions worth reviewing, highest priority first. Mastered
+// questions are left out unless they are due. The given slice isn't modified.
+func rankReviews(questions []types.Question, failures map[uint]int, now time.Time) []types.Question {
+	type ranked struct {
+		question types.Question
+		score    float64
+	}
+
+	var candidates []ranked
+	for _, q := range questions {
+		if !q.Attempted || (q.Mastered && !isDue(q, now)) {
+			continue
+		}
+		candidates = append(candidates, ranked{q, reviewPriority(q, failures[q.ID], now).Score})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].question.ID < candidates[j].question.ID
+	})
+
+	reviews := make([]types.Question, len(candidates))
+	for i, c := range candidates {
+		reviews[i] = c.question
+	}
+	return reviews
+}
+
+// Explain returns why each of the questions is worth practicing right now
+func (s *Service) Explain(questions []types.Question) (map[uint]Priority, error) {
+	attempts, err := s.db.GetAttempts()
+	if err != nil {
+		return nil, fmt.Errorf("loading attempts: %w", err)
+	}
+	failures := failureStreaks(attempts)
+	now := s.clock.Now()
+
+	priorities := make(map[uint]Priority, len(questions))
+	for _, q := range questions {
+		priorities[q.ID] = reviewPriority(q, failures[q.ID], now)
+	}
+	return priorities, nil
+}
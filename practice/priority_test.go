@@ -0,0 +1,76 @@
+package practice
+
+import (
+	"dsacli/types"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReviewPriority(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.Local)
+	reviewed := now.AddDate(0, 0, -4)
+
+	tests := []struct {
+		name      string
+		question  types.Question
+		failures  int
+		wantScore float64
+		reasons   []string
+	}{
+		{
+			name:     "Never attempted",
+			question: types.Question{Difficulty: "medium"},
+			reasons:  []string{"new medium question, never attempted"},
+		},
+		{
+			name:      "Strong and not due",
+			question:  types.Question{Attempted: true, LastPScore: 0.9, LastReviewed: &reviewed, ReviewInterval: 10, EasinessFactor: 2.5},
+			wantScore: 0.3,
+			reasons:   []string{"p-score 0.90"},
+		},
+		{
+			name:      "Weak, overdue and failing",
+			question:  types.Question{Attempted: true, LastPScore: 0.5, LastReviewed: &reviewed, ReviewInterval: 1, EasinessFactor: 2},
+			failures:  5,
+			wantScore: 1.5 + 2 + 4.5 + 1,
+			reasons:   []string{"low p-score 0.50", "3 day(s) overdue", "failed the last 5 recall(s)", "low easiness factor 2.00"},
+		},
+		{
+			name:      "Mastered and due today",
+			question:  types.Question{Attempted: true, Mastered: true, LastPScore: 1, LastReviewed: &reviewed, ReviewInterval: 4, EasinessFactor: 2.5},
+			wantScore: 0,
+			reasons:   []string{"due today", "mastered"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := reviewPriority(tt.question, tt.failures, now)
+			if diff := p.Score - tt.wantScore; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("reviewPriority() score = %v, want %v", p.Score, tt.wantScore)
+			}
+			if !reflect.DeepEqual(p.Reasons, tt.reasons) {
+				t.Errorf("reviewPriority() reasons = %q, want %q", p.Reasons, tt.reasons)
+			}
+		})
+	}
+}
+
+func TestFailureStreaks(t *testing.T) {
+	attempts := []types.Attempt{
+		{QuestionID: 1, PScore: 0.2},
+		{QuestionID: 1, PScore: 0.8},
+		{QuestionID: 1, PScore: 0.4},
+		{QuestionID: 2, PScore: 0.1},
+		{QuestionID: 2, PScore: 0.5},
+		{QuestionID: 3, PScore: 0.3},
+		{QuestionID: 3, PScore: 0.9},
+	}
+
+	got := failureStreaks(attempts)
+	want := map[uint]int{1: 1, 2: 2, 3: 0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("failureStreaks() = %v, want %v", got, want)
+	}
+}
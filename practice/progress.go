@@ -0,0 +1,118 @@
+package practice
+
+import (
+	"dsacli/config"
+	"dsacli/types"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// TierProgress describes how far along the progression gate a difficulty tier is
+type TierProgress struct {
+	Difficulty        string      `json:"difficulty"`
+	Total             int         `json:"total"`
+	Mastered          int         `json:"mastered"`
+	MasteryPercentage float64     `json:"mastery_percentage"`
+	Attempts          int         `json:"attempts"`
+	Gate              config.Gate `json:"gate"`
+	Unlocked          bool        `json:"unlocked"`
+	// Needed is how many more questions must be mastered to meet the mastery criterion
+	Needed int `json:"needed"`
+	// AttemptsNeeded is how many more attempts the minimum attempts criterion asks for
+	AttemptsNeeded int `json:"attempts_needed"`
+	// UncoveredTags are the tags with fewer questions attempted than the tag coverage criterion asks for
+	UncoveredTags []string `json:"uncovered_tags,omitempty"`
+}
+
+// Progress evaluates the progression gate of every difficulty tier
+func (s *Service) Progress() ([]TierProgress, error) {
+	var tiers []TierProgress
+	for _, difficulty := range types.Difficulties {
+		questions, err := s.db.GetQuestionsByDifficulty(difficulty)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s questions: %w", difficulty, err)
+		}
+		tiers = append(tiers, evaluateTier(difficulty, questions, s.settings.Gates.For(difficulty)))
+	}
+	return tiers, nil
+}
+
+// evaluateTier checks every criterion of the gate against the questions of a tier. A tier
+// without questions never unlocks, see gatedPhase for how empty tiers are skipped.
+func evaluateTier(difficulty string, questions []types.Question, gate config.Gate) TierProgress {
+	tier := TierProgress{Difficulty: difficulty, Total: len(questions), Gate: gate}
+	total := make(map[string]int)
+	attempted := make(map[string]int)
+	for _, q := range questions {
+		if q.Mastered {
+			tier.Mastered++
+		}
+		tier.Attempts += q.AttemptCount
+		for _, tag := range q.Tags {
+			total[tag]++
+			if q.Attempted {
+				attempted[tag]++
+			}
+		}
+	}
+	if tier.Total == 0 {
+		return tier
+	}
+	tier.MasteryPercentage = float64(tier.Mastered) / float64(tier.Total) * 100
+
+	if gate.MasteryPercent > 0 {
+		// More than the percentage has to be mastered
+		required := int(math.Floor(float64(tier.Total)*gate.MasteryPercent/100)) + 1
+		tier.Needed = max(required-tier.Mastered, 0)
+	}
+	tier.AttemptsNeeded = max(gate.MinAttempts-tier.Attempts, 0)
+	if gate.TagCoverage > 0 {
+		for tag, n := range total {
+			if float64(attempted[tag])/float64(n)*100 < gate.TagCoverage {
+				tier.UncoveredTags = append(tier.UncoveredTags, tag)
+			}
+		}
+		sort.Strings(tier.UncoveredTags)
+	}
+
+	tier.Unlocked = tier.Needed == 0 && tier.AttemptsNeeded == 0 && len(tier.UncoveredTags) == 0
+	return tier
+}
+
+// gatedPhase returns the tier new questions come from: the lowest tier whose gate is still
+// closed, skipping tiers without questions. Once every gate is open it's the lowest tier with
+// questions left to attempt. It's the mastery phase when every question was attempted, closed
+// gates included, as there is nothing left for them to hold back.
+func gatedPhase(tiers map[string][]types.Question, gates config.Gates) string {
+	attempted := true
+	for _, difficulty := range types.Difficulties {
+		attempted = attempted && allAttempted(tiers[difficulty])
+	}
+	if attempted {
+		return MasteryPhase
+	}
+
+	for _, difficulty := range types.Difficulties {
+		questions := tiers[difficulty]
+		if len(questions) > 0 && !evaluateTier(difficulty, questions, gates.For(difficulty)).Unlocked {
+			return difficulty
+		}
+	}
+	for _, difficulty := range types.Difficulties {
+		if !allAttempted(tiers[difficulty]) {
+			return difficulty
+		}
+	}
+	return MasteryPhase
+}
+
+// nextTier returns the difficulty after the given one, or "" for the last tier
+func nextTier(difficulty string) string {
+	for i, d := range types.Difficulties {
+		if d == difficulty && i+1 < len(types.Difficulties) {
+			return types.Difficulties[i+1]
+		}
+	}
+	return ""
+}
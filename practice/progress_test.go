@@ -0,0 +1,80 @@
+package practice
+
+import (
+	"dsacli/config"
+	"dsacli/types"
+	"reflect"
+	"testing"
+)
+
+func TestEvaluateTier(t *testing.T) {
+	questions := []types.Question{
+		{Mastered: true, Attempted: true, AttemptCount: 2, Tags: []string{"arrays"}},
+		{Attempted: true, AttemptCount: 1, Tags: []string{"arrays"}},
+		{Tags: []string{"graphs"}},
+		{Tags: []string{"graphs", "arrays"}},
+	}
+
+	tests := []struct {
+		name             string
+		gate             config.Gate
+		expectedUnlocked bool
+		expectedNeeded   int
+		expectedAttempts int
+		expectedTags     []string
+	}{
+		{name: "Default gate", gate: config.DefaultGate(), expectedNeeded: 2},
+		{name: "No criteria", gate: config.Gate{}, expectedUnlocked: true},
+		{name: "Minimum attempts met", gate: config.Gate{MinAttempts: 3}, expectedUnlocked: true},
+		{name: "Minimum attempts not met", gate: config.Gate{MinAttempts: 5}, expectedAttempts: 2},
+		{name: "Tag coverage", gate: config.Gate{TagCoverage: 50}, expectedTags: []string{"graphs"}},
+		{name: "Every criterion", gate: config.Gate{MasteryPercent: 20, MinAttempts: 3, TagCoverage: 50}, expectedTags: []string{"graphs"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tier := evaluateTier(types.DifficultyEasy, questions, tt.gate)
+			if tier.Unlocked != tt.expectedUnlocked {
+				t.Errorf("Unlocked = %v, want %v", tier.Unlocked, tt.expectedUnlocked)
+			}
+			if tier.Needed != tt.expectedNeeded {
+				t.Errorf("Needed = %d, want %d", tier.Needed, tt.expectedNeeded)
+			}
+			if tier.AttemptsNeeded != tt.expectedAttempts {
+				t.Errorf("AttemptsNeeded = %d, want %d", tier.AttemptsNeeded, tt.expectedAttempts)
+			}
+			if !reflect.DeepEqual(tier.UncoveredTags, tt.expectedTags) {
+				t.Errorf("UncoveredTags = %v, want %v", tier.UncoveredTags, tt.expectedTags)
+			}
+		})
+	}
+}
+
+func TestGatedPhase(t *testing.T) {
+	gates := config.DefaultSettings().Gates
+	mastered := types.Question{Attempted: true, Mastered: true}
+	attempted := types.Question{Attempted: true}
+	open := types.Question{}
+
+	tests := []struct {
+		name     string
+		tiers    map[string][]types.Question
+		expected string
+	}{
+		{name: "Easy gate closed", tiers: map[string][]types.Question{EasyPhase: {mastered, open}, MediumPhase: {open}}, expected: EasyPhase},
+		{name: "Empty easy tier is skipped", tiers: map[string][]types.Question{MediumPhase: {open}}, expected: MediumPhase},
+		{name: "Hard gate closed", tiers: map[string][]types.Question{EasyPhase: {mastered}, MediumPhase: {mastered}, HardPhase: {open}}, expected: HardPhase},
+		{name: "Every gate open with questions left", tiers: map[string][]types.Question{EasyPhase: {mastered, mastered, open}, HardPhase: {mastered}}, expected: EasyPhase},
+		{name: "Easy gate closed with everything attempted", tiers: map[string][]types.Question{EasyPhase: {mastered, attempted}, MediumPhase: {attempted}}, expected: MasteryPhase},
+		{name: "Hard gate closed with everything attempted", tiers: map[string][]types.Question{EasyPhase: {mastered}, HardPhase: {attempted}}, expected: MasteryPhase},
+		{name: "Everything mastered", tiers: map[string][]types.Question{EasyPhase: {mastered}, HardPhase: {mastered}}, expected: MasteryPhase},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gatedPhase(tt.tiers, gates); got != tt.expected {
+				t.Errorf("gatedPhase() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -1,4 +1,4 @@
-package complete
+package practice
 
 import (
 	"dsacli/types"
@@ -30,15 +30,15 @@ const (
 )
 
 // CalculateScore computes the spaced repetition score based on user feedback
-func CalculateScore(timeTaken, hintsNeeded, optimalSolution, anyBugs int, question types.Question) int {
-	timeRank := calculateTimeRank(timeTaken)
+func CalculateScore(feedback Feedback, question types.Question) int {
+	timeRank := calculateTimeRank(feedback.TimeTaken)
 
-	// Score is the average rating of these 4 parameters
-	averageScore := (float64(hintsNeeded) + timeRank + float64(optimalSolution) + float64(anyBugs)) / 4
+	// Score is the average rating of these 4 parameters, hints are counted so they're rated first
+	averageScore := (float64(hintsRating(feedback.HintsNeeded)) + timeRank + float64(feedback.OptimalSolution) + float64(feedback.AnyBugs)) / 4
 
 	reviewInterval := calculateReviewInterval(question.LastReviewed)
 	solutionMultiplier := calculateSolutionMultiplier(averageScore)
-	timeFactor := calculateTimeFactor(timeTaken)
+	timeFactor := calculateTimeFactor(feedback.TimeTaken)
 
 	newScore := int(math.Round((reviewInterval + timeFactor) * solutionMultiplier))
 
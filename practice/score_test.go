@@ -1,4 +1,4 @@
-package complete
+package practice
 
 import (
 	"dsacli/types"
@@ -19,7 +19,7 @@ func TestCalculateScore(t *testing.T) {
 		{
 			name:            "Perfect solution",
 			timeTaken:       20,
-			hintsNeeded:     5,
+			hintsNeeded:     0,
 			optimalSolution: 5,
 			anyBugs:         5,
 			question:        types.Question{LastPScore: 0},
@@ -28,7 +28,7 @@ func TestCalculateScore(t *testing.T) {
 		{
 			name:            "Unsolved question",
 			timeTaken:       UnsolvedTimeValue,
-			hintsNeeded:     1,
+			hintsNeeded:     4,
 			optimalSolution: 1,
 			anyBugs:         1,
 			question:        types.Question{LastPScore: 0},
@@ -37,7 +37,7 @@ func TestCalculateScore(t *testing.T) {
 		{
 			name:            "With previous score",
 			timeTaken:       30,
-			hintsNeeded:     3,
+			hintsNeeded:     2,
 			optimalSolution: 4,
 			anyBugs:         4,
 			question:        types.Question{LastPScore: 100},
@@ -47,7 +47,8 @@ func TestCalculateScore(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			score := CalculateScore(tt.timeTaken, tt.hintsNeeded, tt.optimalSolution, tt.anyBugs, tt.question)
+			feedback := Feedback{HintsNeeded: tt.hintsNeeded, TimeTaken: tt.timeTaken, OptimalSolution: tt.optimalSolution, AnyBugs: tt.anyBugs}
+			score := CalculateScore(feedback, tt.question)
 
 			if tt.expectPositive && score <= 0 {
 				t.Errorf("Expected positive score, got %d", score)
@@ -152,13 +153,13 @@ func TestCalculateScore_Integration(t *testing.T) {
 	}
 
 	// Good performance
-	score := CalculateScore(20, 5, 5, 5, question)
+	score := CalculateScore(Feedback{HintsNeeded: 0, TimeTaken: 20, OptimalSolution: 5, AnyBugs: 5}, question)
 	if score <= 0 {
 		t.Errorf("Expected positive score for good performance, got %d", score)
 	}
 
 	// Poor performance
-	poorScore := CalculateScore(UnsolvedTimeValue, 1, 1, 1, question)
+	poorScore := CalculateScore(Feedback{HintsNeeded: 4, TimeTaken: UnsolvedTimeValue, OptimalSolution: 1, AnyBugs: 1}, question)
 	if poorScore <= score {
 		t.Errorf("Expected poor performance score (%d) to be higher than good performance score (%d)", poorScore, score)
 	}
@@ -171,6 +172,6 @@ func BenchmarkCalculateScore(b *testing.B) {
 	}
 
 	for i := 0; i < b.N; i++ {
-		CalculateScore(25, 4, 4, 4, question)
+		CalculateScore(Feedback{HintsNeeded: 1, TimeTaken: 25, OptimalSolution: 4, AnyBugs: 4}, question)
 	}
 }
@@ -0,0 +1,111 @@
+// Package practice holds the scheduling, spaced repetition and progress logic shared by
+// the CLI, the TUI, the web dashboard and the API. It never prints: every method returns
+// plain structs and errors for the caller to render.
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/config"
+	"dsacli/db"
+	"dsacli/types"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrQuestionNotFound is returned when a question ID doesn't exist in the question bank
+var ErrQuestionNotFound = errors.New("question not found")
+
+// Service exposes the practice workflow on top of a database
+type Service struct {
+	db       db.Database
+	clock    clock.Clock
+	calendar clock.Calendar
+	rand     *rand.Rand
+	// seed seeds the picks of the next generated plan
+	seed     int64
+	settings config.Settings
+
+	// planning serializes PlanToday, the API server plans from several goroutines
+	planning sync.Mutex
+}
+
+// NewService returns a Service backed by the given database, using the clock, random
+// source and day boundaries of the config
+func NewService(database db.Database, cfg config.Config) *Service {
+	return &Service{
+		db:       database,
+		clock:    cfg.Clock,
+		calendar: cfg.Settings.Calendar(),
+		rand:     cfg.Rand,
+		seed:     cfg.Seed,
+		settings: cfg.Settings,
+	}
+}
+
+// Reseed seeds the random source, which is shared with the config, and the next plan so
+// picks are reproducible
+func (s *Service) Reseed(seed int64) {
+	s.seed = seed
+	s.rand.Seed(seed)
+}
+
+// Now returns the current time according to the service's clock
+func (s *Service) Now() time.Time {
+	return s.clock.Now()
+}
+
+// Date returns the current practice day as YYYY-MM-DD, taking the configured
+// time zone and day start hour into account
+func (s *Service) Date() string {
+	return s.calendar.Date(s.clock.Now())
+}
+
+// previousDate returns the practice day before the current one
+func (s *Service) previousDate() string {
+	return s.calendar.Day(s.clock.Now()).AddDate(0, 0, -1).Format(clock.DateLayout)
+}
+
+// Question returns the question with the given ID
+func (s *Service) Question(id uint) (types.Question, error) {
+	question, err := s.db.FindQuestionByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return types.Question{}, fmt.Errorf("question with ID %d: %w", id, ErrQuestionNotFound)
+	}
+	if err != nil {
+		return types.Question{}, fmt.Errorf("loading question %d: %w", id, err)
+	}
+	return question, nil
+}
+
+// Questions returns the questions matching the filter
+func (s *Service) Questions(filter db.QuestionFilter) ([]types.Question, error) {
+	return s.db.FilterQuestions(filter)
+}
+
+// Due returns the questions due for review, most overdue first
+func (s *Service) Due() ([]types.Question, error) {
+	return s.db.FilterQuestions(db.QuestionFilter{Due: true, Sort: db.SortByDue})
+}
+
+// RecentAttempts returns the latest attempts, newest first
+func (s *Service) RecentAttempts(limit int) ([]types.AttemptWithQuestion, error) {
+	return s.db.GetRecentAttempts(limit)
+}
+
+// SaveNotes replaces the notes of a question
+func (s *Service) SaveNotes(id uint, notes string) (types.Question, error) {
+	question, err := s.Question(id)
+	if err != nil {
+		return types.Question{}, err
+	}
+	question.Notes = notes
+	if err := s.db.UpdateQuestion(question); err != nil {
+		return types.Question{}, fmt.Errorf("saving notes: %w", err)
+	}
+	return question, nil
+}
@@ -0,0 +1,236 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/common"
+	"dsacli/config"
+	"dsacli/db"
+	"dsacli/types"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestService returns a service backed by a fresh SQLite database holding n unattempted easy questions
+func newTestService(t *testing.T, clk clock.Clock, seed int64, n int) *Service {
+	return newTestServiceWithSettings(t, clk, seed, n, config.DefaultSettings())
+}
+
+func newTestServiceWithSettings(t *testing.T, clk clock.Clock, seed int64, n int, settings config.Settings) *Service {
+	t.Helper()
+
+	cfg := config.NewConfig(filepath.Join(t.TempDir(), "test.db"))
+	cfg.Clock = clk
+	cfg.Settings = settings
+	cfg.Rand = common.NewRand(seed)
+	cfg.Seed = seed
+	database, err := db.NewSQLDatabase(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	var questions []types.Question
+	for i := 1; i <= n; i++ {
+		questions = append(questions, types.Question{
+			Name:           fmt.Sprintf("q%d", i),
+			URL:            fmt.Sprintf("https://example.com/q%d", i),
+			Difficulty:     types.DifficultyEasy,
+			EasinessFactor: 2.5,
+		})
+	}
+	if err := database.InsertQuestions(questions); err != nil {
+		t.Fatalf("Failed to insert questions: %v", err)
+	}
+	return NewService(database, cfg)
+}
+
+func planIDs(plan Plan) []uint {
+	var ids []uint
+	for _, q := range plan.Questions {
+		ids = append(ids, q.Question.ID)
+	}
+	return ids
+}
+
+func TestPlanTodayDayBoundary(t *testing.T) {
+	clk := clock.NewAdjustable()
+	clk.Set(time.Date(2024, 3, 10, 23, 59, 0, 0, time.Local))
+	service := newTestService(t, clk, 1, 10)
+
+	first, err := service.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	if !first.Generated || len(first.Questions) != 2 {
+		t.Fatalf("Expected a new plan of 2 questions, got %+v", first)
+	}
+
+	again, err := service.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	if again.Generated {
+		t.Errorf("Expected the same day to reuse the saved plan")
+	}
+
+	clk.Advance(2 * time.Minute)
+	next, err := service.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	if !next.Generated {
+		t.Errorf("Expected a new plan after midnight")
+	}
+}
+
+func TestPlanTodaySeeded(t *testing.T) {
+	now := clock.Fixed(time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local))
+
+	a, err := newTestService(t, now, 42, 20).PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	b, err := newTestService(t, now, 42, 20).PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+
+	if fmt.Sprint(planIDs(a)) != fmt.Sprint(planIDs(b)) {
+		t.Errorf("Expected the same seed to pick the same questions, got %v and %v", planIDs(a), planIDs(b))
+	}
+}
+
+func TestPlanTodayTraceSeedReproducesLaterPlans(t *testing.T) {
+	day := time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)
+	clkA, clkB := clock.NewManual(day), clock.NewManual(day)
+	a, b := newTestService(t, clkA, 42, 20), newTestService(t, clkB, 42, 20)
+	for _, service := range []*Service{a, b} {
+		if _, err := service.PlanToday(false); err != nil {
+			t.Fatalf("PlanToday() unexpected error: %v", err)
+		}
+	}
+
+	// The second plan of a long-running service is reproduced by reseeding with its trace seed
+	clkA.Advance(24 * time.Hour)
+	later, err := a.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	if later.Trace == nil || later.Trace.Seed == 42 {
+		t.Fatalf("Expected the second plan to record its own seed, got %+v", later.Trace)
+	}
+	clkB.Advance(24 * time.Hour)
+	b.Reseed(later.Trace.Seed)
+	replayed, err := b.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	if fmt.Sprint(planIDs(later)) != fmt.Sprint(planIDs(replayed)) {
+		t.Errorf("Expected seed %d to reproduce %v, got %v", later.Trace.Seed, planIDs(later), planIDs(replayed))
+	}
+}
+
+func TestRecordAttemptUsesClock(t *testing.T) {
+	now := time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)
+	service := newTestService(t, clock.Fixed(now), 1, 2)
+
+	if _, err := service.PlanToday(false); err != nil {
+		t.Fatalf("PlanToday() unexpected error: %v", err)
+	}
+	result, err := service.RecordAttempt(1, Feedback{TimeTaken: 10, OptimalSolution: 5, AnyBugs: 5})
+	if err != nil {
+		t.Fatalf("RecordAttempt() unexpected error: %v", err)
+	}
+
+	if !result.Question.LastReviewed.Equal(now) || result.Attempt.Date != "2024-03-10" {
+		t.Errorf("Expected attempt to be recorded at %v, got %v (%s)", now, result.Question.LastReviewed, result.Attempt.Date)
+	}
+
+	plan, err := service.Today()
+	if err != nil {
+		t.Fatalf("Today() unexpected error: %v", err)
+	}
+	if plan.Completed() != 1 {
+		t.Errorf("Expected 1 completed question in today's plan, got %d", plan.Completed())
+	}
+}
+
+func TestDeleteQuestionRemovesAttempts(t *testing.T) {
+	service := newTestService(t, clock.Fixed(time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)), 1, 2)
+	for _, id := range []uint{1, 2} {
+		if _, err := service.RecordAttempt(id, Feedback{TimeTaken: 10, OptimalSolution: 5, AnyBugs: 5}); err != nil {
+			t.Fatalf("RecordAttempt() unexpected error: %v", err)
+		}
+	}
+
+	if err := service.db.DeleteQuestion(1); err != nil {
+		t.Fatalf("DeleteQuestion() unexpected error: %v", err)
+	}
+	attempts, err := service.db.GetAttempts()
+	if err != nil {
+		t.Fatalf("GetAttempts() unexpected error: %v", err)
+	}
+	if len(attempts) != 1 || attempts[0].QuestionID != 2 {
+		t.Errorf("Expected only the attempt on question 2 to be left, got %+v", attempts)
+	}
+}
+
+func TestRecordAttemptAfterMidnight(t *testing.T) {
+	tests := []struct {
+		name         string
+		dayStartHour int
+		expectedDate string
+		expectedPlan int // questions in the plan of the day the attempt is made
+	}{
+		{name: "Day starts at midnight", dayStartHour: 0, expectedDate: "2024-03-10", expectedPlan: 0},
+		{name: "Day starts at 4am", dayStartHour: 4, expectedDate: "2024-03-10", expectedPlan: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewAdjustable()
+			clk.Set(time.Date(2024, 3, 10, 23, 50, 0, 0, time.UTC))
+			service := newTestServiceWithSettings(t, clk, 1, 4, config.Settings{Timezone: "UTC", DayStartHour: tt.dayStartHour})
+
+			plan, err := service.PlanToday(false)
+			if err != nil {
+				t.Fatalf("PlanToday() unexpected error: %v", err)
+			}
+			questionID := plan.Questions[0].Question.ID
+
+			clk.Advance(20 * time.Minute)
+			open, err := service.OpenQuestions()
+			if err != nil {
+				t.Fatalf("OpenQuestions() unexpected error: %v", err)
+			}
+			if len(open) != 2 {
+				t.Fatalf("Expected the 2 questions started before midnight to still be open, got %d", len(open))
+			}
+
+			result, err := service.RecordAttempt(questionID, Feedback{TimeTaken: 25, OptimalSolution: 4, AnyBugs: 4})
+			if err != nil {
+				t.Fatalf("RecordAttempt() unexpected error: %v", err)
+			}
+			if result.Attempt.Date != tt.expectedDate {
+				t.Errorf("Expected attempt to count for %s, got %s", tt.expectedDate, result.Attempt.Date)
+			}
+
+			open, err = service.OpenQuestions()
+			if err != nil {
+				t.Fatalf("OpenQuestions() unexpected error: %v", err)
+			}
+			if len(open) != 1 {
+				t.Errorf("Expected 1 open question after completing one, got %d", len(open))
+			}
+
+			today, err := service.Today()
+			if err != nil {
+				t.Fatalf("Today() unexpected error: %v", err)
+			}
+			if len(today.Questions) != tt.expectedPlan {
+				t.Errorf("Expected %d questions in the current plan, got %d", tt.expectedPlan, len(today.Questions))
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package practice
+
+import (
+	"dsacli/types"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultSimilarLimit is the number of similar questions returned by default
+	DefaultSimilarLimit = 5
+
+	// Weights of the similarity components
+	sharedTagWeight   = 1.0
+	sectionWeight     = 1.0
+	performanceWeight = 0.5
+)
+
+// Similar is a question practicing the same pattern as another one, with why it was ranked there
+type Similar struct {
+	Question types.Question `json:"question"`
+	Score    float64        `json:"score"`
+	Reasons  []string       `json:"reasons"`
+}
+
+// similarity scores how well a candidate practices the same pattern as the question: every
+// shared tag and the same problem list section count, and the candidates still worth
+// practicing, never attempted or with a low p-score, come first. ok is false when the
+// candidate shares neither a tag nor the section.
+func similarity(question, candidate types.Question) (Similar, bool) {
+	s := Similar{Question: candidate}
+
+	var shared []string
+	for _, tag := range question.Tags {
+		if tag != types.LeechTag && candidate.HasTag(tag) {
+			shared = append(shared, tag)
+		}
+	}
+	if len(shared) > 0 {
+		s.Score += float64(len(shared)) * sharedTagWeight
+		s.Reasons = append(s.Reasons, "shares "+strings.Join(shared, ", "))
+	}
+	if question.Section != "" && strings.EqualFold(question.Section, candidate.Section) {
+		s.Score += sectionWeight
+		s.Reasons = append(s.Reasons, fmt.Sprintf("same section %q", candidate.Section))
+	}
+	if s.Score == 0 {
+		return Similar{}, false
+	}
+
+	switch {
+	case !candidate.Attempted:
+		s.Score += performanceWeight
+		s.Reasons = append(s.Reasons, "never attempted")
+	case !candidate.Mastered:
+		s.Score += (1 - candidate.LastPScore) * performanceWeight
+		s.Reasons = append(s.Reasons, fmt.Sprintf("p-score %.2f", candidate.LastPScore))
+	default:
+		s.Reasons = append(s.Reasons, "mastered")
+	}
+	return s, true
+}
+
+// rankSimilar returns up to limit of the candidates most similar to the question, best first
+func rankSimilar(question types.Question, candidates []types.Question, limit int) []Similar {
+	similar := []Similar{}
+	for _, c := range candidates {
+		if c.ID == question.ID {
+			continue
+		}
+		if s, ok := similarity(question, c); ok {
+			similar = append(similar, s)
+		}
+	}
+	sort.SliceStable(similar, func(i, j int) bool {
+		if similar[i].Score != similar[j].Score {
+			return similar[i].Score > similar[j].Score
+		}
+		return similar[i].Question.ID < similar[j].Question.ID
+	})
+	return similar[:min(limit, len(similar))]
+}
+
+// Similar returns up to limit of the questions that can be scheduled that practice the same
+// pattern as the question with the given ID, best first. Questions held back by their
+// prerequisites are left out.
+func (s *Service) Similar(id uint, limit int) ([]Similar, error) {
+	question, err := s.Question(id)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.db.GetActiveQuestions()
+	if err != nil {
+		return nil, fmt.Errorf("loading questions: %w", err)
+	}
+	dependencies, err := s.db.GetDependencies()
+	if err != nil {
+		return nil, fmt.Errorf("loading prerequisites: %w", err)
+	}
+	questions = filterOutQuestions(questions, blockedQuestions(questions, dependencies))
+	return rankSimilar(question, questions, limit), nil
+}
+
+// QueueSimilar adds a question to today's plan to practice the same pattern as the question it
+// is similar to right away. Questions already in today's plan or held back by their prerequisites
+// can't be queued.
+func (s *Service) QueueSimilar(similar Similar, to types.Question) error {
+	date := s.Date()
+	_, planned, err := s.db.GetTodayQuestions(date)
+	if err != nil {
+		return fmt.Errorf("loading today's questions: %w", err)
+	}
+	for _, entry := range planned {
+		if entry.QuestionID == similar.Question.ID {
+			return fmt.Errorf("'%s' is already in today's plan", similar.Question.Name)
+		}
+	}
+	blocked, err := s.blocked()
+	if err != nil {
+		return err
+	}
+	for _, id := range blocked {
+		if id == similar.Question.ID {
+			return fmt.Errorf("'%s' is held back until its prerequisites are attempted", similar.Question.Name)
+		}
+	}
+
+	kind := types.PickReview
+	if !similar.Question.Attempted {
+		kind = types.PickNew
+	}
+	pick := types.Pick{
+		Kind:    kind,
+		Score:   similar.Score,
+		Reasons: append([]string{"similar to " + to.Name}, similar.Reasons...),
+	}
+	picks := map[uint]types.Pick{similar.Question.ID: pick}
+	if err := s.db.InsertTodayQuestions(date, []types.Question{similar.Question}, picks); err != nil {
+		return fmt.Errorf("queueing '%s': %w", similar.Question.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/types"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRankSimilar(t *testing.T) {
+	question := types.Question{ID: 1, Tags: []string{"graphs", "bfs", types.LeechTag}, Section: "Graphs"}
+
+	tests := []struct {
+		name       string
+		candidates []types.Question
+		limit      int
+		want       []uint
+	}{
+		{
+			name: "More shared tags first",
+			candidates: []types.Question{
+				{ID: 2, Tags: []string{"graphs"}, Attempted: true, Mastered: true},
+				{ID: 3, Tags: []string{"graphs", "bfs"}, Attempted: true, Mastered: true},
+			},
+			limit: 5,
+			want:  []uint{3, 2},
+		},
+		{
+			name: "Same section counts like a shared tag",
+			candidates: []types.Question{
+				{ID: 2, Tags: []string{"graphs"}, Attempted: true, Mastered: true},
+				{ID: 3, Tags: []string{"graphs"}, Section: "graphs", Attempted: true, Mastered: true},
+			},
+			limit: 5,
+			want:  []uint{3, 2},
+		},
+		{
+			name: "Never attempted and low p-score before mastered",
+			candidates: []types.Question{
+				{ID: 2, Tags: []string{"graphs"}, Attempted: true, Mastered: true},
+				{ID: 3, Tags: []string{"graphs"}, Attempted: true, LastPScore: 0.4},
+				{ID: 4, Tags: []string{"graphs"}},
+			},
+			limit: 5,
+			want:  []uint{4, 3, 2},
+		},
+		{
+			name: "Unrelated, leech tag only and itself are skipped",
+			candidates: []types.Question{
+				{ID: 1, Tags: []string{"graphs"}},
+				{ID: 2, Tags: []string{"arrays"}},
+				{ID: 3, Tags: []string{types.LeechTag}},
+			},
+			limit: 5,
+			want:  []uint{},
+		},
+		{
+			name: "Limited",
+			candidates: []types.Question{
+				{ID: 2, Tags: []string{"graphs"}},
+				{ID: 3, Tags: []string{"bfs"}},
+				{ID: 4, Tags: []string{"graphs", "bfs"}},
+			},
+			limit: 2,
+			want:  []uint{4, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []uint{}
+			for _, s := range rankSimilar(question, tt.candidates, tt.limit) {
+				got = append(got, s.Question.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rankSimilar() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueueSimilar(t *testing.T) {
+	service := newTestService(t, clock.Fixed(time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)), 1, 3)
+	for _, id := range []uint{1, 2, 3} {
+		q, err := service.Question(id)
+		if err != nil {
+			t.Fatalf("Question(%d) unexpected error: %v", id, err)
+		}
+		q.Tags = []string{"graphs"}
+		if err := service.db.UpdateQuestion(q); err != nil {
+			t.Fatalf("UpdateQuestion() unexpected error: %v", err)
+		}
+	}
+
+	question, _ := service.Question(1)
+	similar, err := service.Similar(question.ID, DefaultSimilarLimit)
+	if err != nil {
+		t.Fatalf("Similar() unexpected error: %v", err)
+	}
+	if len(similar) != 2 {
+		t.Fatalf("Similar() returned %d questions, want 2", len(similar))
+	}
+
+	if err := service.QueueSimilar(similar[0], question); err != nil {
+		t.Fatalf("QueueSimilar() unexpected error: %v", err)
+	}
+	questions, entries, err := service.db.GetTodayQuestions(service.Date())
+	if err != nil {
+		t.Fatalf("GetTodayQuestions() unexpected error: %v", err)
+	}
+	if len(questions) != 1 || questions[0].ID != similar[0].Question.ID {
+		t.Fatalf("today's plan = %v, want only question %d", questions, similar[0].Question.ID)
+	}
+	if entries[0].Pick.Kind != types.PickNew || entries[0].Pick.Reasons[0] != "similar to q1" {
+		t.Errorf("queued pick = %+v, want a new pick similar to q1", entries[0].Pick)
+	}
+
+	if err := service.QueueSimilar(similar[0], question); err == nil {
+		t.Errorf("QueueSimilar() twice expected an error")
+	}
+}
+
+func TestSimilarSkipsBlocked(t *testing.T) {
+	service := newTestService(t, clock.Fixed(time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)), 1, 3)
+	for _, id := range []uint{1, 2, 3} {
+		q, err := service.Question(id)
+		if err != nil {
+			t.Fatalf("Question(%d) unexpected error: %v", id, err)
+		}
+		q.Tags = []string{"graphs"}
+		if err := service.db.UpdateQuestion(q); err != nil {
+			t.Fatalf("UpdateQuestion() unexpected error: %v", err)
+		}
+	}
+	// q3 is held back until q2 is attempted
+	if err := service.db.InsertDependencies([]types.QuestionDependency{{QuestionID: 3, PrerequisiteID: 2}}); err != nil {
+		t.Fatalf("InsertDependencies() unexpected error: %v", err)
+	}
+
+	question, _ := service.Question(1)
+	similar, err := service.Similar(question.ID, DefaultSimilarLimit)
+	if err != nil {
+		t.Fatalf("Similar() unexpected error: %v", err)
+	}
+	if len(similar) != 1 || similar[0].Question.ID != 2 {
+		t.Fatalf("Similar() = %+v, want only q2", similar)
+	}
+
+	blocked, _ := service.Question(3)
+	if err := service.QueueSimilar(Similar{Question: blocked}, question); err == nil {
+		t.Errorf("QueueSimilar() of a blocked question expected an error")
+	}
+}
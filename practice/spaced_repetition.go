@@ -1,25 +1,20 @@
-package complete
+package practice
 
 import (
+	"dsacli/config"
 	"dsacli/types"
 	"math"
 )
 
-// CalculatePScore computes the performance score based on user feedback
-// Returns a float between 0.0 and 1.0
-func CalculatePScore(timeTaken, hintsUsed, optimality, bugs int) float64 {
+// ConfidenceWeight is the share of the p-score given to the self-reported confidence
+const ConfidenceWeight = 0.2
+
+// CalculatePScore computes the performance score based on user feedback, the time taken
+// being scored against the minutes expected for the question's difficulty.
+// Returns a float between 0.0 and 1.0. A confidence of 0 means it wasn't reported.
+func CalculatePScore(timeTaken, targetMinutes, hintsUsed, optimality, bugs, confidence int) float64 {
 	// Maximum 40% weightage for time taken - Score gets converted to 0.0-0.4 scale
-	var timeScore float64
-	switch {
-	case timeTaken == -1: // Unsolved
-		timeScore = 0.0
-	case timeTaken <= 30:
-		timeScore = 0.4
-	case timeTaken <= 45:
-		timeScore = 0.2
-	default: // timeTaken > 45
-		timeScore = 0.1
-	}
+	timeScore := TimeScore(timeTaken, targetMinutes)
 
 	// Hint score calculation (convert 1-5 scale to 0.0-0.3)
 	var hintScore float64
@@ -35,16 +30,37 @@ func CalculatePScore(timeTaken, hintsUsed, optimality, bugs int) float64 {
 	// Bug score calculation (convert 1-5 scale to 0.0-0.15)
 	bugScore := float64(bugs-1) / 4.0 * 0.15
 
-	return timeScore + hintScore + optimalityScore + bugScore
+	pScore := timeScore + hintScore + optimalityScore + bugScore
+	if confidence == 0 {
+		return pScore
+	}
+
+	// Blend in the confidence (convert 1-5 scale to 0.0-1.0), a shaky solve is recalled less reliably
+	confidenceScore := float64(confidence-1) / 4.0
+	return pScore*(1-ConfidenceWeight) + confidenceScore*ConfidenceWeight
+}
+
+// TimeScore scores the time taken on a 0.0-0.4 scale. A solve within the target minutes gets
+// the full 0.4, a slower one decays with the square of the overrun: 0.2 at about 1.4 times the
+// target and 0.1 at twice the target. An unsolved problem scores 0.
+func TimeScore(timeTaken, targetMinutes int) float64 {
+	if timeTaken == UnsolvedTimeValue || timeTaken <= 0 {
+		return 0.0
+	}
+	if timeTaken <= targetMinutes {
+		return 0.4
+	}
+	ratio := float64(targetMinutes) / float64(timeTaken)
+	return 0.4 * ratio * ratio
 }
 
 // ProcessReview updates the question based on spaced repetition logic after user completes a problem
-func ProcessReview(question *types.Question, timeTaken, hintsUsed, optimality, bugs int) {
+func ProcessReview(question *types.Question, timeTaken, targetMinutes, hintsUsed, optimality, bugs, confidence int) {
 	// Increment attempt count
 	question.AttemptCount++
 
 	// Calculate current p-score
-	currentPScore := CalculatePScore(timeTaken, hintsUsed, optimality, bugs)
+	currentPScore := CalculatePScore(timeTaken, targetMinutes, hintsUsed, optimality, bugs, confidence)
 
 	// Check for Progression Mastery (if not already achieved)
 	if !question.Mastered {
@@ -100,20 +116,8 @@ func ProcessReview(question *types.Question, timeTaken, hintsUsed, optimality, b
 	question.LastPScore = currentPScore
 }
 
-// CheckProgressionGate determines if a difficulty tier is unlocked
+// CheckProgressionGate determines if a difficulty tier is unlocked under the default gate
 // Returns true if > 50% of questions in the category have progression mastery
 func CheckProgressionGate(categoryQuestions []types.Question) bool {
-	if len(categoryQuestions) == 0 {
-		return false
-	}
-
-	masteredCount := 0
-	for _, q := range categoryQuestions {
-		if q.Mastered {
-			masteredCount++
-		}
-	}
-
-	masteryPercentage := float64(masteredCount) / float64(len(categoryQuestions)) * 100
-	return masteryPercentage > 50.0
+	return evaluateTier("", categoryQuestions, config.DefaultGate()).Unlocked
 }
@@ -1,7 +1,8 @@
-package complete
+package practice
 
 import (
 	"dsacli/types"
+	"math"
 	"testing"
 )
 
@@ -9,14 +10,17 @@ func TestCalculatePScore(t *testing.T) {
 	tests := []struct {
 		name       string
 		timeTaken  int
+		target     int
 		hintsUsed  int
 		optimality int
 		bugs       int
+		confidence int
 		expected   float64
 	}{
 		{
 			name:       "Perfect performance",
-			timeTaken:  25,  // ≤ 30 minutes → 0.4
+			timeTaken:  25, // Within the target → 0.4
+			target:     30,
 			hintsUsed:  0,   // No hints → 0.3
 			optimality: 5,   // Very optimal → 0.15
 			bugs:       5,   // No bugs → 0.15
@@ -24,7 +28,8 @@ func TestCalculatePScore(t *testing.T) {
 		},
 		{
 			name:       "Unsolved problem",
-			timeTaken:  -1,    // Unsolved → 0.0
+			timeTaken:  -1, // Unsolved → 0.0
+			target:     30,
 			hintsUsed:  3,     // 3 hints → 0.3/4 = 0.075
 			optimality: 1,     // Not optimal → 0.0
 			bugs:       1,     // Many bugs → 0.0
@@ -32,32 +37,106 @@ func TestCalculatePScore(t *testing.T) {
 		},
 		{
 			name:       "Medium performance",
-			timeTaken:  35,     // 30-45 minutes → 0.2
-			hintsUsed:  1,      // 1 hint → 0.3/2 = 0.15
-			optimality: 3,      // Middle → (3-1)/4 * 0.15 = 0.075
-			bugs:       4,      // Few bugs → (4-1)/4 * 0.15 = 0.1125
-			expected:   0.5375, // 0.2 + 0.15 + 0.075 + 0.1125
+			timeTaken:  35, // 0.4 * (30/35)² = 0.2939
+			target:     30,
+			hintsUsed:  1, // 1 hint → 0.3/2 = 0.15
+			optimality: 3, // Middle → (3-1)/4 * 0.15 = 0.075
+			bugs:       4, // Few bugs → (4-1)/4 * 0.15 = 0.1125
+			expected:   0.4*(30.0/35)*(30.0/35) + 0.15 + 0.075 + 0.1125,
 		},
 		{
 			name:       "Slow performance",
-			timeTaken:  50,     // > 45 minutes → 0.1
+			timeTaken:  50, // 0.4 * (30/50)² = 0.144
+			target:     30,
 			hintsUsed:  2,      // 2 hints → 0.3/3 = 0.1
 			optimality: 2,      // Low optimal → (2-1)/4 * 0.15 = 0.0375
 			bugs:       3,      // Some bugs → (3-1)/4 * 0.15 = 0.075
-			expected:   0.3125, // 0.1 + 0.1 + 0.0375 + 0.075
+			expected:   0.3565, // 0.144 + 0.1 + 0.0375 + 0.075
+		},
+		{
+			name:       "Perfect performance, fully confident",
+			timeTaken:  25,
+			target:     30,
+			hintsUsed:  0,
+			optimality: 5,
+			bugs:       5,
+			confidence: 5,   // (5-1)/4 → 1.0
+			expected:   1.0, // 1.0 * 0.8 + 1.0 * 0.2
+		},
+		{
+			name:       "Perfect performance, not confident",
+			timeTaken:  25,
+			target:     30,
+			hintsUsed:  0,
+			optimality: 5,
+			bugs:       5,
+			confidence: 1,   // (1-1)/4 → 0.0
+			expected:   0.8, // 1.0 * 0.8 + 0.0 * 0.2
+		},
+		{
+			name:       "Unsolved problem, somewhat confident",
+			timeTaken:  -1,
+			target:     30,
+			hintsUsed:  3,
+			optimality: 1,
+			bugs:       1,
+			confidence: 3,    // (3-1)/4 → 0.5
+			expected:   0.16, // 0.075 * 0.8 + 0.5 * 0.2
+		},
+		{
+			name:       "40 minute hard solve",
+			timeTaken:  40, // Within the 45 minute hard target → 0.4
+			target:     45,
+			hintsUsed:  0,   // No hints → 0.3
+			optimality: 5,   // Very optimal → 0.15
+			bugs:       5,   // No bugs → 0.15
+			expected:   1.0, // 0.4 + 0.3 + 0.15 + 0.15
+		},
+		{
+			name:       "40 minute easy solve",
+			timeTaken:  40, // Twice the 20 minute easy target → 0.1
+			target:     20,
+			hintsUsed:  0,   // No hints → 0.3
+			optimality: 5,   // Very optimal → 0.15
+			bugs:       5,   // No bugs → 0.15
+			expected:   0.7, // 0.1 + 0.3 + 0.15 + 0.15
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := CalculatePScore(tt.timeTaken, tt.hintsUsed, tt.optimality, tt.bugs)
-			if result != tt.expected {
+			result := CalculatePScore(tt.timeTaken, tt.target, tt.hintsUsed, tt.optimality, tt.bugs, tt.confidence)
+			if math.Abs(result-tt.expected) > 1e-9 {
 				t.Errorf("CalculatePScore() = %v, want %v", result, tt.expected)
 			}
 		})
 	}
 }
 
+func TestTimeScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		timeTaken int
+		target    int
+		expected  float64
+	}{
+		{name: "Unsolved", timeTaken: UnsolvedTimeValue, target: 30, expected: 0},
+		{name: "Faster than the target", timeTaken: 10, target: 30, expected: 0.4},
+		{name: "On the target", timeTaken: 30, target: 30, expected: 0.4},
+		{name: "Just over the target", timeTaken: 31, target: 30, expected: 0.4 * (30.0 / 31) * (30.0 / 31)},
+		{name: "Twice the target", timeTaken: 60, target: 30, expected: 0.1},
+		{name: "Four times the target", timeTaken: 180, target: 45, expected: 0.025},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TimeScore(tt.timeTaken, tt.target); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("TimeScore(%d, %d) = %v, want %v", tt.timeTaken, tt.target, got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestProcessReview(t *testing.T) {
 	t.Run("Instant mastery on first perfect attempt", func(t *testing.T) {
 		question := &types.Question{
@@ -68,7 +147,7 @@ func TestProcessReview(t *testing.T) {
 		}
 
 		// Perfect performance on first try
-		ProcessReview(question, 20, 0, 5, 5) // p-score = 1.0
+		ProcessReview(question, 20, 30, 0, 5, 5, 0) // p-score = 1.0
 
 		if question.AttemptCount != 1 {
 			t.Errorf("Expected AttemptCount = 1, got %d", question.AttemptCount)
@@ -98,7 +177,7 @@ func TestProcessReview(t *testing.T) {
 		}
 
 		// Good performance on second try
-		ProcessReview(question, 25, 0, 5, 5) // p-score = 1.0
+		ProcessReview(question, 25, 30, 0, 5, 5, 0) // p-score = 1.0
 
 		if question.AttemptCount != 2 {
 			t.Errorf("Expected AttemptCount = 2, got %d", question.AttemptCount)
@@ -127,7 +206,7 @@ func TestProcessReview(t *testing.T) {
 		}
 
 		// Poor performance
-		ProcessReview(question, -1, 5, 1, 1) // p-score = 0.0375
+		ProcessReview(question, -1, 30, 5, 1, 1, 0) // p-score = 0.0375
 
 		if question.AttemptCount != 3 {
 			t.Errorf("Expected AttemptCount = 3, got %d", question.AttemptCount)
@@ -153,7 +232,7 @@ func TestProcessReview(t *testing.T) {
 		}
 
 		// Poor performance that would lower EF below 1.3
-		ProcessReview(question, -1, 5, 1, 1)
+		ProcessReview(question, -1, 30, 5, 1, 1, 0)
 
 		if question.EasinessFactor != 1.3 {
 			t.Errorf("Expected EasinessFactor = 1.3 (minimum), got %f", question.EasinessFactor)
@@ -0,0 +1,27 @@
+package practice
+
+import (
+	"dsacli/types"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ErrNoStatement is returned when no problem statement is stored for a question
+var ErrNoStatement = errors.New("no statement stored")
+
+// Statement returns the problem statement stored for a question to practice it offline
+func (s *Service) Statement(id uint) (types.QuestionContent, error) {
+	if _, err := s.Question(id); err != nil {
+		return types.QuestionContent{}, err
+	}
+	content, err := s.db.GetQuestionContent(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return types.QuestionContent{}, fmt.Errorf("question with ID %d: %w", id, ErrNoStatement)
+	}
+	if err != nil {
+		return types.QuestionContent{}, fmt.Errorf("loading the statement of question %d: %w", id, err)
+	}
+	return content, nil
+}
@@ -0,0 +1,42 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/types"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStatement(t *testing.T) {
+	service := newTestService(t, clock.Fixed(time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)), 1, 2)
+	content := types.QuestionContent{QuestionID: 1, Statement: "Find two numbers.", Examples: "1 2"}
+	if err := service.db.SaveQuestionContents([]types.QuestionContent{content}); err != nil {
+		t.Fatalf("SaveQuestionContents() unexpected error: %v", err)
+	}
+
+	got, err := service.Statement(1)
+	if err != nil || got != content {
+		t.Errorf("Statement(1) = %+v, %v, want %+v", got, err, content)
+	}
+	details, err := service.Details(1)
+	if err != nil || !details.HasStatement {
+		t.Errorf("Details(1) has statement = %v, %v, want true", details.HasStatement, err)
+	}
+
+	// Importing again replaces the statement
+	content.Statement = "Find two numbers adding up to target."
+	if err := service.db.SaveQuestionContents([]types.QuestionContent{content}); err != nil {
+		t.Fatalf("SaveQuestionContents() unexpected error: %v", err)
+	}
+	if got, _ := service.Statement(1); got != content {
+		t.Errorf("Statement(1) after reimport = %+v, want %+v", got, content)
+	}
+
+	if _, err := service.Statement(2); !errors.Is(err, ErrNoStatement) {
+		t.Errorf("Statement(2) error = %v, want ErrNoStatement", err)
+	}
+	if _, err := service.Statement(99); !errors.Is(err, ErrQuestionNotFound) {
+		t.Errorf("Statement(99) error = %v, want ErrQuestionNotFound", err)
+	}
+}
@@ -0,0 +1,165 @@
+package practice
+
+import (
+	"dsacli/types"
+	"fmt"
+	"sort"
+)
+
+// TrendPoint is the average p-score of all attempts made on a day
+type TrendPoint struct {
+	Date     string  `json:"date"`
+	PScore   float64 `json:"p_score"`
+	Attempts int     `json:"attempts"`
+}
+
+// FailureStat is how many attempts went wrong for a failure category
+type FailureStat struct {
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+	// Percentage is the share of the attempts that reported a failure category
+	Percentage float64 `json:"percentage"`
+}
+
+// Status summarises the practice progress
+type Status struct {
+	TotalQuestions int              `json:"total_questions"`
+	Attempted      int              `json:"attempted"`
+	Mastered       int              `json:"mastered"`
+	Due            int              `json:"due"`
+	Streak         int              `json:"streak"`
+	TotalAttempts  int              `json:"total_attempts"`
+	Tiers          []TierProgress   `json:"tiers"`
+	AttemptsByDate map[string]int   `json:"attempts_by_date"`
+	PScoreTrend    []TrendPoint     `json:"p_score_trend"`
+	Failures       []FailureStat    `json:"failures"`            // most frequent first
+	Companies      []Coverage       `json:"companies,omitempty"` // coverage of the questions each company asks
+	Lists          []Coverage       `json:"lists,omitempty"`     // coverage of each curated list
+	Readiness      *Readiness       `json:"readiness,omitempty"` // set while an interview goal is active
+	MasteredQns    []types.Question `json:"-"`
+	NonMasteredQns []types.Question `json:"-"`
+}
+
+// Status computes the current practice progress
+func (s *Service) Status() (Status, error) {
+	questions, err := s.db.GetActiveQuestions()
+	if err != nil {
+		return Status{}, fmt.Errorf("loading questions: %w", err)
+	}
+	due, err := s.Due()
+	if err != nil {
+		return Status{}, fmt.Errorf("loading due questions: %w", err)
+	}
+	tiers, err := s.Progress()
+	if err != nil {
+		return Status{}, err
+	}
+	attempts, err := s.db.GetAttempts()
+	if err != nil {
+		return Status{}, fmt.Errorf("loading attempts: %w", err)
+	}
+	attemptsByDate, err := s.db.GetAttemptCountsByDate()
+	if err != nil {
+		return Status{}, fmt.Errorf("loading attempt counts: %w", err)
+	}
+
+	status := Status{
+		TotalQuestions: len(questions),
+		Due:            len(due),
+		Streak:         CalculateStreak(attemptsByDate, s.calendar.Day(s.clock.Now())),
+		TotalAttempts:  len(attempts),
+		Tiers:          tiers,
+		AttemptsByDate: attemptsByDate,
+		PScoreTrend:    buildTrend(attempts),
+		Failures:       buildFailureStats(attempts),
+		Companies:      buildCoverage(questions, func(q types.Question) []string { return q.Companies }),
+		Lists:          buildCoverage(questions, func(q types.Question) []string { return q.Lists }),
+	}
+	for _, q := range questions {
+		if !q.Attempted {
+			continue
+		}
+		status.Attempted++
+		if q.Mastered {
+			status.Mastered++
+			status.MasteredQns = append(status.MasteredQns, q)
+		} else {
+			status.NonMasteredQns = append(status.NonMasteredQns, q)
+		}
+	}
+
+	goal, hasGoal, err := s.ActiveGoal()
+	if err != nil {
+		return Status{}, err
+	}
+	if hasGoal {
+		readiness, err := s.readiness(goal, questions, attempts)
+		if err != nil {
+			return Status{}, err
+		}
+		status.Readiness = &readiness
+	}
+
+	return status, nil
+}
+
+// Streak returns the number of consecutive days with at least one attempt
+func (s *Service) Streak() (int, error) {
+	attemptsByDate, err := s.db.GetAttemptCountsByDate()
+	if err != nil {
+		return 0, fmt.Errorf("loading attempt counts: %w", err)
+	}
+	return CalculateStreak(attemptsByDate, s.calendar.Day(s.clock.Now())), nil
+}
+
+// buildFailureStats counts the attempts of each failure category, most frequent first.
+// Categories no attempt reported are left out.
+func buildFailureStats(attempts []types.Attempt) []FailureStat {
+	counts := make(map[string]int)
+	total := 0
+	for _, a := range attempts {
+		if a.FailureCategory != "" {
+			counts[a.FailureCategory]++
+			total++
+		}
+	}
+
+	stats := []FailureStat{}
+	for _, category := range types.FailureCategories {
+		if counts[category] > 0 {
+			stats = append(stats, FailureStat{
+				Category:   category,
+				Count:      counts[category],
+				Percentage: float64(counts[category]) / float64(total) * 100,
+			})
+		}
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].Count > stats[j].Count
+	})
+	return stats
+}
+
+// buildTrend averages the p-score of the attempts made on each day
+func buildTrend(attempts []types.Attempt) []TrendPoint {
+	byDate := make(map[string]*TrendPoint)
+	for _, a := range attempts {
+		point, ok := byDate[a.Date]
+		if !ok {
+			point = &TrendPoint{Date: a.Date}
+			byDate[a.Date] = point
+		}
+		point.PScore += a.PScore
+		point.Attempts++
+	}
+
+	trend := make([]TrendPoint, 0, len(byDate))
+	for _, point := range byDate {
+		point.PScore /= float64(point.Attempts)
+		trend = append(trend, *point)
+	}
+	sort.Slice(trend, func(i, j int) bool {
+		return trend[i].Date < trend[j].Date
+	})
+	return trend
+}
@@ -0,0 +1,30 @@
+package practice
+
+import (
+	"dsacli/types"
+	"testing"
+)
+
+func TestBuildFailureStats(t *testing.T) {
+	attempts := []types.Attempt{
+		{FailureCategory: types.FailureEdgeCases},
+		{FailureCategory: types.FailurePattern},
+		{FailureCategory: types.FailureEdgeCases},
+		{FailureCategory: types.FailureEdgeCases},
+		{},
+	}
+
+	got := buildFailureStats(attempts)
+	want := []FailureStat{
+		{Category: types.FailureEdgeCases, Count: 3, Percentage: 75},
+		{Category: types.FailurePattern, Count: 1, Percentage: 25},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("buildFailureStats() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("buildFailureStats()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
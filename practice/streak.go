@@ -0,0 +1,19 @@
+package practice
+
+import "time"
+
+// CalculateStreak returns the number of consecutive days, ending today, with at least one attempt.
+// A streak that ended yesterday is still counted since today's questions may not be done yet.
+func CalculateStreak(attemptsByDate map[string]int, today time.Time) int {
+	day := today
+	if attemptsByDate[day.Format("2006-01-02")] == 0 {
+		day = day.AddDate(0, 0, -1)
+	}
+
+	streak := 0
+	for attemptsByDate[day.Format("2006-01-02")] > 0 {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak
+}
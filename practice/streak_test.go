@@ -0,0 +1,38 @@
+package practice
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculateStreak(t *testing.T) {
+	today := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		attempts map[string]int
+		expected int
+	}{
+		{"No attempts", map[string]int{}, 0},
+		{"Only today", map[string]int{"2026-10-18": 2}, 1},
+		{"Ended yesterday", map[string]int{"2026-10-17": 1, "2026-10-16": 1}, 2},
+		{"Gap breaks streak", map[string]int{"2026-10-18": 1, "2026-10-17": 1, "2026-10-15": 3}, 2},
+		{"Last attempt two days ago", map[string]int{"2026-10-16": 1}, 0},
+		{"Across month boundary", map[string]int{"2026-10-02": 1, "2026-10-01": 1, "2026-09-30": 1}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if streak := CalculateStreak(tt.attempts, today); streak != tt.expected {
+				t.Errorf("CalculateStreak() = %d, want %d", streak, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Across month boundary ending today", func(t *testing.T) {
+		attempts := map[string]int{"2026-10-01": 1, "2026-09-30": 1, "2026-09-29": 1}
+		if streak := CalculateStreak(attempts, time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)); streak != 3 {
+			t.Errorf("CalculateStreak() = %d, want 3", streak)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package practice
+
+import (
+	"dsacli/config"
+	"dsacli/types"
+	"fmt"
+)
+
+// MinLearnedSolves is how many solves of a difficulty it takes before its time target can be
+// learned from them
+const MinLearnedSolves = 5
+
+// TimeTargets returns the minutes a solve of each difficulty is expected to take. These are the
+// configured targets unless learning is enabled, in which case the median time of the user's
+// own solves replaces the target of every difficulty solved at least MinLearnedSolves times.
+func (s *Service) TimeTargets() (config.TimeTargets, error) {
+	if !s.settings.LearnTimeTargets {
+		return s.settings.TimeTargets, nil
+	}
+	attempts, err := s.db.GetAttempts()
+	if err != nil {
+		return config.TimeTargets{}, fmt.Errorf("loading attempts: %w", err)
+	}
+	questions, err := s.db.GetAllQuestions()
+	if err != nil {
+		return config.TimeTargets{}, fmt.Errorf("loading questions: %w", err)
+	}
+	return s.timeTargets(attempts, questions), nil
+}
+
+// timeTargets returns the time targets given the attempt history
+func (s *Service) timeTargets(attempts []types.Attempt, questions []types.Question) config.TimeTargets {
+	if !s.settings.LearnTimeTargets {
+		return s.settings.TimeTargets
+	}
+	return learnTimeTargets(s.settings.TimeTargets, attempts, questions)
+}
+
+// learnTimeTargets replaces each configured target by the median time of the solved attempts
+// of that difficulty, if there are enough of them
+func learnTimeTargets(targets config.TimeTargets, attempts []types.Attempt, questions []types.Question) config.TimeTargets {
+	difficulties := make(map[uint]string, len(questions))
+	for _, q := range questions {
+		difficulties[q.ID] = q.Difficulty
+	}
+	solves := make(map[string][]int)
+	for _, a := range attempts {
+		if a.TimeTaken > 0 {
+			difficulty := difficulties[a.QuestionID]
+			solves[difficulty] = append(solves[difficulty], a.TimeTaken)
+		}
+	}
+
+	learned := targets
+	for difficulty, times := range map[string]*int{
+		types.DifficultyEasy:   &learned.Easy,
+		types.DifficultyMedium: &learned.Medium,
+		types.DifficultyHard:   &learned.Hard,
+	} {
+		if len(solves[difficulty]) >= MinLearnedSolves {
+			*times = median(solves[difficulty])
+		}
+	}
+	return learned
+}
@@ -0,0 +1,94 @@
+package practice
+
+import (
+	"dsacli/clock"
+	"dsacli/config"
+	"dsacli/types"
+	"testing"
+	"time"
+)
+
+func TestLearnTimeTargets(t *testing.T) {
+	questions := []types.Question{
+		{ID: 1, Difficulty: types.DifficultyEasy},
+		{ID: 2, Difficulty: types.DifficultyHard},
+	}
+	solves := func(questionID uint, times ...int) []types.Attempt {
+		var attempts []types.Attempt
+		for _, minutes := range times {
+			attempts = append(attempts, types.Attempt{QuestionID: questionID, TimeTaken: minutes})
+		}
+		return attempts
+	}
+
+	tests := []struct {
+		name     string
+		attempts []types.Attempt
+		want     config.TimeTargets
+	}{
+		{
+			name: "No attempts",
+			want: config.DefaultTimeTargets(),
+		},
+		{
+			name:     "Too few solves",
+			attempts: solves(2, 60, 70, 80, 90),
+			want:     config.DefaultTimeTargets(),
+		},
+		{
+			name:     "Unsolved attempts don't count",
+			attempts: solves(2, 60, 70, 80, 90, UnsolvedTimeValue),
+			want:     config.DefaultTimeTargets(),
+		},
+		{
+			name:     "Median of the hard solves",
+			attempts: solves(2, 90, 60, 70, 80, 55),
+			want:     config.TimeTargets{Easy: 20, Medium: 30, Hard: 70},
+		},
+		{
+			name:     "Each difficulty learned on its own",
+			attempts: append(solves(1, 10, 12, 15, 11, 14, 13), solves(2, 50, 50, 50, 50, 50)...),
+			want:     config.TimeTargets{Easy: 13, Medium: 30, Hard: 50},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := learnTimeTargets(config.DefaultTimeTargets(), tt.attempts, questions); got != tt.want {
+				t.Errorf("learnTimeTargets() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordAttemptUsesTimeTarget(t *testing.T) {
+	clk := clock.Fixed(time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local))
+	feedback := Feedback{TimeTaken: 30, OptimalSolution: 5, AnyBugs: 5}
+
+	settings := config.DefaultSettings()
+	settings.TimeTargets.Easy = 15
+	service := newTestServiceWithSettings(t, clk, 1, 1, settings)
+	result, err := service.RecordAttempt(1, feedback)
+	if err != nil {
+		t.Fatalf("RecordAttempt() unexpected error: %v", err)
+	}
+	if want := CalculatePScore(30, 15, 0, 5, 5, NoConfidence); result.Attempt.PScore != want {
+		t.Errorf("RecordAttempt() p-score = %v, want %v against the easy target", result.Attempt.PScore, want)
+	}
+
+	// Once enough easy questions were solved in 30 minutes, that becomes the target
+	settings.LearnTimeTargets = true
+	service = newTestServiceWithSettings(t, clk, 1, MinLearnedSolves+1, settings)
+	for id := uint(1); id <= MinLearnedSolves; id++ {
+		if _, err := service.RecordAttempt(id, feedback); err != nil {
+			t.Fatalf("RecordAttempt() unexpected error: %v", err)
+		}
+	}
+	result, err = service.RecordAttempt(MinLearnedSolves+1, feedback)
+	if err != nil {
+		t.Fatalf("RecordAttempt() unexpected error: %v", err)
+	}
+	if result.Attempt.PScore != 1 {
+		t.Errorf("RecordAttempt() p-score = %v, want 1 with the learned target", result.Attempt.PScore)
+	}
+}
@@ -0,0 +1,165 @@
+package practice
+
+import (
+	"dsacli/types"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// maxTraceCandidates is the number of scored candidates kept in a trace
+const maxTraceCandidates = 10
+
+// Candidate is a question the planner scored while picking
+type Candidate struct {
+	QuestionID uint    `json:"question_id"`
+	Name       string  `json:"name"`
+	Score      float64 `json:"score"`
+	Picked     bool    `json:"picked"`
+}
+
+// Trace records the decisions the planner made while generating a plan
+type Trace struct {
+	Phase string `json:"phase"`
+	Seed  int64  `json:"seed"`
+	// Pools is the number of candidates of each kind of pick the questions were picked among
+	Pools map[string]int `json:"pools"`
+	// Blocked is the number of new questions held back until their prerequisites are attempted
+	Blocked int `json:"blocked,omitempty"`
+	// Companies are the focus companies new questions were picked from first
+	Companies []string `json:"companies,omitempty"`
+	// Candidates are the scored candidates, best first
+	Candidates []Candidate `json:"candidates,omitempty"`
+	// TieBreaks tell how picks between equally scored candidates were decided
+	TieBreaks []string `json:"tie_breaks,omitempty"`
+	// Picks tells why each question was picked, by question ID
+	Picks map[uint]types.Pick `json:"picks"`
+}
+
+func newTrace(seed int64) *Trace {
+	return &Trace{Seed: seed, Pools: make(map[string]int), Picks: make(map[uint]types.Pick)}
+}
+
+// Every method below is a no-op on a nil trace so the pickers can be used without one
+
+// pick records why a question was picked, the kind follows from whether it was attempted
+func (t *Trace) pick(q types.Question, pool int, score float64, reasons ...string) {
+	if t == nil {
+		return
+	}
+	kind := types.PickReview
+	if !q.Attempted {
+		kind = types.PickNew
+	}
+	t.Pools[kind] = max(t.Pools[kind], pool)
+	t.Picks[q.ID] = types.Pick{Kind: kind, Pool: pool, Score: score, Reasons: reasons}
+}
+
+// reason adds a reason to the pick of a question
+func (t *Trace) reason(id uint, reason string) {
+	if t == nil {
+		return
+	}
+	if p, ok := t.Picks[id]; ok {
+		p.Reasons = append(p.Reasons, reason)
+		t.Picks[id] = p
+	}
+}
+
+// pickRandom records a question drawn at random from the unattempted questions of a pool
+func (t *Trace) pickRandom(q types.Question, unattempted int) {
+	t.pick(q, unattempted, 0, fmt.Sprintf("random pick among %d unattempted question(s)", unattempted))
+}
+
+// pickFocus records a question picked by getFocusQuestion from the pool
+func (t *Trace) pickFocus(q types.Question, pool []types.Question) {
+	if !q.Attempted {
+		t.pickRandom(q, len(filterUnattemptedQuestions(pool)))
+		return
+	}
+	t.pickHighestPScore(q, pool)
+	t.reason(q.ID, "no unattempted question left")
+}
+
+// pickHighestPScore records a question picked by getHighestSRQuestion from the pool
+func (t *Trace) pickHighestPScore(q types.Question, pool []types.Question) {
+	if t == nil {
+		return
+	}
+	t.pick(q, len(pool), q.LastPScore, fmt.Sprintf("highest p-score %.2f among %d attempted question(s)", q.LastPScore, len(pool)))
+
+	ranked := append([]types.Question(nil), pool...)
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].LastPScore > ranked[j].LastPScore })
+	for _, c := range ranked[:min(maxTraceCandidates, len(ranked))] {
+		t.Candidates = append(t.Candidates, Candidate{QuestionID: c.ID, Name: c.Name, Score: c.LastPScore, Picked: c.ID == q.ID})
+	}
+
+	tied := 0
+	for _, c := range pool {
+		if c.ID != q.ID && c.LastPScore == q.LastPScore {
+			tied++
+		}
+	}
+	if tied > 0 {
+		t.TieBreaks = append(t.TieBreaks, fmt.Sprintf("%s tied with %d other question(s) at p-score %.2f, the first in the bank wins", q.Name, tied, q.LastPScore))
+	}
+}
+
+// pickRanked records the reviews picked from the ones ranked by rankReviews
+func (t *Trace) pickRanked(picked, ranked []types.Question, failures map[uint]int, now time.Time) {
+	if t == nil || len(picked) == 0 {
+		return
+	}
+	isPicked := make(map[uint]bool)
+	for _, q := range picked {
+		isPicked[q.ID] = true
+	}
+
+	scores := make([]float64, len(ranked))
+	for i, q := range ranked {
+		priority := reviewPriority(q, failures[q.ID], now)
+		scores[i] = priority.Score
+		if isPicked[q.ID] {
+			t.pick(q, len(ranked), priority.Score, priority.Reasons...)
+		}
+		if i < maxTraceCandidates {
+			t.Candidates = append(t.Candidates, Candidate{QuestionID: q.ID, Name: q.Name, Score: priority.Score, Picked: isPicked[q.ID]})
+		}
+	}
+
+	for i := 1; i < len(ranked); i++ {
+		a, b := ranked[i-1], ranked[i]
+		if scores[i-1] == scores[i] && isPicked[a.ID] != isPicked[b.ID] {
+			t.TieBreaks = append(t.TieBreaks, fmt.Sprintf("%s and %s tied at priority %.2f, the lower ID wins", a.Name, b.Name, scores[i]))
+		}
+	}
+}
+
+// leaks marks the new questions picked from the tier after the phase's
+func (t *Trace) leaks(questions []types.Question, phase string) {
+	next := nextTier(phase)
+	for _, q := range questions {
+		if next != "" && q.Difficulty == next && !q.Attempted {
+			t.reason(q.ID, fmt.Sprintf("leaked early from the %s tier", next))
+		}
+	}
+}
+
+// finish keeps the picks of the questions that made it into the plan and stamps them
+// with the phase and seed
+func (t *Trace) finish(phase string, questions []types.Question) {
+	if t == nil {
+		return
+	}
+	t.Phase = phase
+	picks := make(map[uint]types.Pick, len(questions))
+	for _, q := range questions {
+		p, ok := t.Picks[q.ID]
+		if !ok {
+			continue
+		}
+		p.Phase, p.Seed = phase, t.Seed
+		picks[q.ID] = p
+	}
+	t.Picks = picks
+}
@@ -0,0 +1,104 @@
+package practice
+
+import (
+	"dsacli/db/dbtest"
+	"dsacli/types"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTracePickRanked(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.Local)
+	reviewed := now.AddDate(0, 0, -5)
+	review := func(id uint, pScore float64) types.Question {
+		return types.Question{ID: id, Name: string(rune('a' + id)), Attempted: true, LastPScore: pScore, LastReviewed: &reviewed, ReviewInterval: 1}
+	}
+	ranked := []types.Question{review(1, 0.4), review(2, 0.5), review(3, 0.5)}
+
+	trace := newTrace(42)
+	trace.pickRanked(ranked[:2], ranked, map[uint]int{1: 2}, now)
+	trace.finish(MasteryPhase, ranked[:2])
+
+	if got := trace.Pools[types.PickReview]; got != 3 {
+		t.Errorf("review pool = %d, want 3", got)
+	}
+	if len(trace.Candidates) != 3 || !trace.Candidates[0].Picked || trace.Candidates[2].Picked {
+		t.Errorf("candidates = %+v, want 3 with the first two picked", trace.Candidates)
+	}
+	if len(trace.TieBreaks) != 1 {
+		t.Errorf("tie breaks = %q, want one between b and c", trace.TieBreaks)
+	}
+
+	pick, ok := trace.Picks[1]
+	if !ok {
+		t.Fatalf("no pick recorded for question 1")
+	}
+	want := types.Pick{
+		Phase:   MasteryPhase,
+		Kind:    types.PickReview,
+		Pool:    3,
+		Score:   pick.Score,
+		Reasons: []string{"low p-score 0.40", "4 day(s) overdue", "failed the last 2 recall(s)"},
+		Seed:    42,
+	}
+	if !reflect.DeepEqual(pick, want) {
+		t.Errorf("pick = %+v, want %+v", pick, want)
+	}
+	if _, ok := trace.Picks[3]; ok {
+		t.Errorf("question 3 wasn't picked but has a pick")
+	}
+}
+
+func TestTracePickFocus(t *testing.T) {
+	pool := []types.Question{
+		createTestQuestion(1, "q1", "easy", true, 0.8),
+		createTestQuestion(2, "q2", "easy", false, 0),
+		createTestQuestion(3, "q3", "easy", false, 0),
+	}
+
+	trace := newTrace(0)
+	trace.pickFocus(pool[1], pool)
+	trace.pickFocus(pool[0], pool[:1])
+
+	if got := trace.Picks[2]; got.Kind != types.PickNew || got.Pool != 2 {
+		t.Errorf("pick of an unattempted question = %+v, want a new pick among 2", got)
+	}
+	if got := trace.Picks[1]; got.Kind != types.PickReview || got.Score != 0.8 || len(got.Reasons) != 2 {
+		t.Errorf("pick of an attempted question = %+v, want a review by p-score", got)
+	}
+}
+
+func TestTraceNil(t *testing.T) {
+	var trace *Trace
+	q := createTestQuestion(1, "q1", "easy", false, 0)
+	trace.pickFocus(q, []types.Question{q})
+	trace.pickRanked([]types.Question{q}, []types.Question{q}, nil, time.Now())
+	trace.reason(q.ID, "reason")
+	trace.finish(EasyPhase, []types.Question{q})
+}
+
+func TestPlanTodayStoresPicks(t *testing.T) {
+	mockDB := &dbtest.MockDatabase{
+		QuestionsByDifficulty: map[string][]types.Question{
+			EasyPhase: {createTestQuestion(1, "e1", "easy", false, 0), createTestQuestion(2, "e2", "easy", false, 0)},
+		},
+	}
+	service := NewService(mockDB, testConfig)
+
+	plan, err := service.PlanToday(false)
+	if err != nil {
+		t.Fatalf("PlanToday() error = %v", err)
+	}
+	if plan.Trace == nil || plan.Trace.Phase != EasyPhase {
+		t.Fatalf("PlanToday() trace = %+v, want an easy phase trace", plan.Trace)
+	}
+	if len(mockDB.InsertedPicks) != 2 {
+		t.Errorf("stored picks = %v, want one per question", mockDB.InsertedPicks)
+	}
+	for _, q := range plan.Questions {
+		if q.Pick == nil || q.Pick.Kind != types.PickNew || q.Pick.Phase != EasyPhase {
+			t.Errorf("pick of %s = %+v, want a new easy pick", q.Question.Name, q.Pick)
+		}
+	}
+}
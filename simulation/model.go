@@ -0,0 +1,162 @@
+package simulation
+
+import (
+	"dsacli/config"
+	"dsacli/practice"
+	"dsacli/types"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+)
+
+// LearnerModel describes a synthetic learner. The chance of solving a question decays
+// from Recall towards the cold-start skill of its difficulty as time since the last review
+// passes, with a half-life (stability) that grows with every repetition.
+type LearnerModel struct {
+	// Skill is the chance of solving a question of each difficulty without any memory of it
+	Skill map[string]float64 `json:"skill"`
+	// Recall is the chance of solving a question right after reviewing it
+	Recall float64 `json:"recall"`
+	// StabilityDays is the initial half-life in days of the memory of a question
+	StabilityDays float64 `json:"stability_days"`
+	// SuccessGrowth multiplies the stability after a successful attempt
+	SuccessGrowth float64 `json:"success_growth"`
+	// FailureGrowth multiplies the stability after a failed attempt
+	FailureGrowth float64 `json:"failure_growth"`
+	// SolveMinutes is the typical time to solve a question of each difficulty
+	SolveMinutes map[string]float64 `json:"solve_minutes"`
+	// PracticeChance is the chance of practicing at all on a given day
+	PracticeChance float64 `json:"practice_chance"`
+}
+
+// DefaultLearnerModel is a learner who practices every day and forgets at a typical rate
+func DefaultLearnerModel() LearnerModel {
+	return LearnerModel{
+		Skill:          map[string]float64{types.DifficultyEasy: 0.7, types.DifficultyMedium: 0.4, types.DifficultyHard: 0.15},
+		Recall:         0.95,
+		StabilityDays:  3,
+		SuccessGrowth:  2.5,
+		FailureGrowth:  1.3,
+		SolveMinutes:   defaultSolveMinutes(),
+		PracticeChance: 1,
+	}
+}
+
+// defaultSolveMinutes takes the typical solve times from the default time targets
+func defaultSolveMinutes() map[string]float64 {
+	targets := config.DefaultTimeTargets()
+	minutes := make(map[string]float64)
+	for _, difficulty := range types.Difficulties {
+		minutes[difficulty] = float64(targets.For(difficulty))
+	}
+	return minutes
+}
+
+// LoadLearnerModel reads a model from a JSON file. Fields missing from the file keep
+// their default value.
+func LoadLearnerModel(path string) (LearnerModel, error) {
+	model := DefaultLearnerModel()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return model, fmt.Errorf("reading learner model: %w", err)
+	}
+	if err := json.Unmarshal(data, &model); err != nil {
+		return model, fmt.Errorf("parsing learner model %s: %w", path, err)
+	}
+	if err := model.Validate(); err != nil {
+		return model, fmt.Errorf("invalid learner model %s: %w", path, err)
+	}
+	return model, nil
+}
+
+// Validate checks that every parameter is in range
+func (m LearnerModel) Validate() error {
+	for _, difficulty := range types.Difficulties {
+		skill, ok := m.Skill[difficulty]
+		if !ok || skill < 0 || skill > 1 {
+			return fmt.Errorf("skill for %s must be between 0 and 1", difficulty)
+		}
+		if m.SolveMinutes[difficulty] <= 0 {
+			return fmt.Errorf("solve_minutes for %s must be > 0", difficulty)
+		}
+	}
+	if m.Recall < 0 || m.Recall > 1 {
+		return fmt.Errorf("recall must be between 0 and 1")
+	}
+	if m.StabilityDays <= 0 || m.SuccessGrowth <= 0 || m.FailureGrowth <= 0 {
+		return fmt.Errorf("stability_days, success_growth and failure_growth must be > 0")
+	}
+	if m.PracticeChance < 0 || m.PracticeChance > 1 {
+		return fmt.Errorf("practice_chance must be between 0 and 1")
+	}
+	return nil
+}
+
+// memory is what the learner remembers of a question
+type memory struct {
+	repetitions  int
+	stability    float64
+	lastReviewed int // simulated day
+}
+
+// successChance returns the chance of solving a question on the given day
+func (m LearnerModel) successChance(difficulty string, mem *memory, day int) float64 {
+	skill := m.Skill[difficulty]
+	if mem == nil {
+		return skill
+	}
+	elapsed := float64(day - mem.lastReviewed)
+	retention := math.Pow(2, -elapsed/mem.stability)
+	return math.Max(skill, skill+(m.Recall-skill)*retention)
+}
+
+// attempt simulates solving a question and returns the feedback the learner would give
+func (m LearnerModel) attempt(rng *rand.Rand, question types.Question, mem *memory, day int) (practice.Feedback, bool) {
+	solved := rng.Float64() < m.successChance(question.Difficulty, mem, day)
+	minutes := m.SolveMinutes[question.Difficulty]
+	if mem != nil {
+		// Familiar questions go faster
+		minutes /= 1 + 0.2*float64(mem.repetitions)
+	}
+
+	if !solved {
+		if rng.Float64() < 0.5 {
+			return practice.Feedback{TimeTaken: practice.UnsolvedTimeValue, HintsNeeded: 2 + rng.Intn(2), OptimalSolution: 1 + rng.Intn(2), AnyBugs: 1 + rng.Intn(2)}, false
+		}
+		return practice.Feedback{TimeTaken: solveTime(minutes * 1.8), HintsNeeded: 1 + rng.Intn(2), OptimalSolution: 2 + rng.Intn(2), AnyBugs: 2 + rng.Intn(2)}, false
+	}
+
+	hints := 0
+	if rng.Float64() < 0.2 {
+		hints = 1
+	}
+	return practice.Feedback{
+		TimeTaken:       solveTime(minutes * (0.6 + 0.6*rng.Float64())),
+		HintsNeeded:     hints,
+		OptimalSolution: 4 + rng.Intn(2),
+		AnyBugs:         3 + rng.Intn(3),
+	}, true
+}
+
+// solveTime rounds the simulated minutes down to a time the feedback accepts, so slow learners
+// are recorded at the longest time rather than failing validation
+func solveTime(minutes float64) int {
+	return min(max(1, int(minutes)), practice.MaxMinutes)
+}
+
+// remember updates the learner's memory of a question after an attempt
+func (m LearnerModel) remember(mem *memory, solved bool, day int) *memory {
+	if mem == nil {
+		mem = &memory{stability: m.StabilityDays}
+	} else if solved {
+		mem.stability *= m.SuccessGrowth
+	} else {
+		mem.stability *= m.FailureGrowth
+	}
+	mem.repetitions++
+	mem.lastReviewed = day
+	return mem
+}
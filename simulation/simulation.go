@@ -0,0 +1,222 @@
+// Package simulation replays the scheduler against a synthetic learner in a throwaway
+// database, to evaluate scheduling changes before shipping them
+package simulation
+
+import (
+	"dsacli/clock"
+	"dsacli/common"
+	"dsacli/config"
+	"dsacli/db"
+	"dsacli/practice"
+	"dsacli/types"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Options configure a simulation run
+type Options struct {
+	Days     int
+	Model    LearnerModel
+	Settings config.Settings
+	Start    time.Time
+}
+
+// DayStats is what happened on one simulated day
+type DayStats struct {
+	Day          int  `json:"day"`
+	Practiced    bool `json:"practiced"`
+	NewQuestions int  `json:"new_questions"`
+	Reviews      int  `json:"reviews"`
+	Solved       int  `json:"solved"`
+	Due          int  `json:"due"` // questions due for review at the end of the day
+}
+
+// TierUnlock is the first day a difficulty tier passed the progression gate, or -1 if it never did
+type TierUnlock struct {
+	Difficulty string `json:"difficulty"`
+	Day        int    `json:"day"`
+}
+
+// Report summarises a simulation run
+type Report struct {
+	Days        []DayStats              `json:"days"`
+	TierUnlocks []TierUnlock            `json:"tier_unlocks"`
+	FinalTiers  []practice.TierProgress `json:"final_tiers"`
+	Attempts    int                     `json:"attempts"`
+	Solved      int                     `json:"solved"`
+	Attempted   int                     `json:"attempted"`
+	Mastered    int                     `json:"mastered"`
+	Questions   int                     `json:"questions"`
+}
+
+// ReviewsPerDay returns the average and the highest number of reviews over the practiced days
+func (r Report) ReviewsPerDay() (float64, int) {
+	total, practiced, peak := 0, 0, 0
+	for _, day := range r.Days {
+		if !day.Practiced {
+			continue
+		}
+		practiced++
+		total += day.Reviews
+		peak = max(peak, day.Reviews)
+	}
+	if practiced == 0 {
+		return 0, 0
+	}
+	return float64(total) / float64(practiced), peak
+}
+
+// Run simulates the learner practicing the questions for the given number of days, starting
+// from scratch, with the prerequisites between them. rng drives both the learner and the
+// planner so runs are reproducible.
+func Run(questions []types.Question, dependencies []types.QuestionDependency, opts Options, rng *rand.Rand) (Report, error) {
+	if opts.Days <= 0 {
+		return Report{}, fmt.Errorf("days must be > 0")
+	}
+
+	clk := clock.NewManual(opts.Start)
+	cfg := config.NewConfig(db.InMemoryPath)
+	cfg.Clock = clk
+	cfg.Seed = rng.Int63()
+	cfg.Rand = common.NewRand(cfg.Seed)
+	cfg.Settings = opts.Settings
+
+	database, err := db.NewSQLDatabase(cfg)
+	if err != nil {
+		return Report{}, fmt.Errorf("creating simulation database: %w", err)
+	}
+	copies := freshCopies(questions)
+	if err := database.InsertQuestions(copies); err != nil {
+		return Report{}, fmt.Errorf("loading questions: %w", err)
+	}
+	if err := database.InsertDependencies(remapDependencies(dependencies, questions, copies)); err != nil {
+		return Report{}, fmt.Errorf("loading prerequisites: %w", err)
+	}
+	service := practice.NewService(database, cfg)
+
+	report := Report{Questions: len(questions)}
+	unlocked := make(map[string]int)
+	memories := make(map[uint]*memory)
+
+	// Practice around noon so the day start setting never splits a simulated day
+	start := opts.Settings.Calendar().Day(opts.Start).Add(12 * time.Hour)
+	for day := 0; day < opts.Days; day++ {
+		clk.Set(start.AddDate(0, 0, day))
+		stats := DayStats{Day: day + 1}
+
+		if rng.Float64() < opts.Model.PracticeChance {
+			stats.Practiced = true
+			if err := practiceDay(service, opts.Model, rng, memories, day, &stats); err != nil {
+				return report, fmt.Errorf("day %d: %w", day+1, err)
+			}
+		}
+
+		due, err := service.Due()
+		if err != nil {
+			return report, err
+		}
+		stats.Due = len(due)
+
+		tiers, err := service.Progress()
+		if err != nil {
+			return report, err
+		}
+		for _, tier := range tiers {
+			if _, ok := unlocked[tier.Difficulty]; !ok && tier.Unlocked {
+				unlocked[tier.Difficulty] = day + 1
+			}
+		}
+
+		report.Attempts += stats.NewQuestions + stats.Reviews
+		report.Solved += stats.Solved
+		report.Days = append(report.Days, stats)
+	}
+
+	final, err := service.Progress()
+	if err != nil {
+		return report, err
+	}
+	report.FinalTiers = final
+	for _, tier := range final {
+		day, ok := unlocked[tier.Difficulty]
+		if !ok {
+			day = -1
+		}
+		report.TierUnlocks = append(report.TierUnlocks, TierUnlock{Difficulty: tier.Difficulty, Day: day})
+		report.Mastered += tier.Mastered
+	}
+	report.Attempted = len(memories)
+
+	return report, nil
+}
+
+// practiceDay works through the day's plan like the learner would
+func practiceDay(service *practice.Service, model LearnerModel, rng *rand.Rand, memories map[uint]*memory, day int, stats *DayStats) error {
+	plan, err := service.PlanToday(false)
+	if err != nil {
+		return err
+	}
+
+	for _, planned := range plan.Questions {
+		if planned.Completed {
+			continue
+		}
+		question := planned.Question
+		mem := memories[question.ID]
+
+		feedback, solved := model.attempt(rng, question, mem, day)
+		if _, err := service.RecordAttempt(question.ID, feedback); err != nil {
+			return err
+		}
+
+		if mem == nil {
+			stats.NewQuestions++
+		} else {
+			stats.Reviews++
+		}
+		if solved {
+			stats.Solved++
+		}
+		memories[question.ID] = model.remember(mem, solved, day)
+	}
+	return nil
+}
+
+// freshCopies returns the questions without any review history
+func freshCopies(questions []types.Question) []types.Question {
+	copies := make([]types.Question, 0, len(questions))
+	for _, q := range questions {
+		copies = append(copies, types.Question{
+			Name:           q.Name,
+			URL:            q.URL,
+			Difficulty:     q.Difficulty,
+			Tags:           q.Tags,
+			Section:        q.Section,
+			Companies:      q.Companies,
+			Lists:          q.Lists,
+			Platform:       q.Platform,
+			Premium:        q.Premium,
+			EasinessFactor: 2.5,
+		})
+	}
+	return copies
+}
+
+// remapDependencies returns the prerequisites between the questions using the IDs of their
+// copies, which are in the same order. Prerequisites on other questions are dropped.
+func remapDependencies(dependencies []types.QuestionDependency, questions, copies []types.Question) []types.QuestionDependency {
+	ids := make(map[uint]uint, len(questions))
+	for i, q := range questions {
+		ids[q.ID] = copies[i].ID
+	}
+	var remapped []types.QuestionDependency
+	for _, d := range dependencies {
+		question, ok := ids[d.QuestionID]
+		prerequisite, ok2 := ids[d.PrerequisiteID]
+		if ok && ok2 {
+			remapped = append(remapped, types.QuestionDependency{QuestionID: question, PrerequisiteID: prerequisite})
+		}
+	}
+	return remapped
+}
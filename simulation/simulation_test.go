@@ -0,0 +1,154 @@
+package simulation
+
+import (
+	"dsacli/common"
+	"dsacli/config"
+	"dsacli/types"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testQuestions() []types.Question {
+	var questions []types.Question
+	counts := map[string]int{types.DifficultyEasy: 8, types.DifficultyMedium: 6, types.DifficultyHard: 3}
+	for _, difficulty := range types.Difficulties {
+		for i := 0; i < counts[difficulty]; i++ {
+			name := fmt.Sprintf("%s-%d", difficulty, i)
+			questions = append(questions, types.Question{Name: name, URL: "https://example.com/" + name, Difficulty: difficulty})
+		}
+	}
+	return questions
+}
+
+func TestRun(t *testing.T) {
+	opts := Options{
+		Days:     60,
+		Model:    DefaultLearnerModel(),
+		Settings: config.DefaultSettings(),
+		Start:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local),
+	}
+
+	report, err := Run(testQuestions(), nil, opts, common.NewRand(1))
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	if len(report.Days) != opts.Days {
+		t.Errorf("Expected %d simulated days, got %d", opts.Days, len(report.Days))
+	}
+	// Mastered questions that aren't due are left alone, so only days with reviews due fill up
+	for _, day := range report.Days {
+		if day.Due > 0 && day.NewQuestions+day.Reviews != 2 {
+			t.Errorf("Expected 2 attempts on a day with reviews due, got %+v", day)
+		}
+	}
+	if report.Days[0].Reviews != 0 || report.Days[0].NewQuestions != 2 {
+		t.Errorf("Expected the first day to have 2 new questions and no reviews, got %+v", report.Days[0])
+	}
+	if report.Attempted == 0 || report.Attempted > report.Questions {
+		t.Errorf("Unexpected number of attempted questions: %d of %d", report.Attempted, report.Questions)
+	}
+	if len(report.TierUnlocks) != len(types.Difficulties) {
+		t.Errorf("Expected an unlock entry per tier, got %v", report.TierUnlocks)
+	}
+
+	again, err := Run(testQuestions(), nil, opts, common.NewRand(1))
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(report, again) {
+		t.Errorf("Expected the same seed to produce the same report")
+	}
+}
+
+func TestRunSkippedDays(t *testing.T) {
+	model := DefaultLearnerModel()
+	model.PracticeChance = 0
+
+	report, err := Run(testQuestions(), nil, Options{Days: 5, Model: model, Settings: config.DefaultSettings(), Start: time.Now()}, common.NewRand(1))
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if report.Attempts != 0 {
+		t.Errorf("Expected no attempts when the learner never practices, got %d", report.Attempts)
+	}
+	if avg, peak := report.ReviewsPerDay(); avg != 0 || peak != 0 {
+		t.Errorf("Expected no reviews, got avg %.1f peak %d", avg, peak)
+	}
+}
+
+func TestRunSlowLearner(t *testing.T) {
+	model := DefaultLearnerModel()
+	for _, difficulty := range types.Difficulties {
+		model.SolveMinutes[difficulty] = 400
+	}
+
+	report, err := Run(testQuestions(), nil, Options{Days: 5, Model: model, Settings: config.DefaultSettings(), Start: time.Now()}, common.NewRand(1))
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if report.Attempts == 0 {
+		t.Errorf("Expected the slow learner's attempts to be recorded")
+	}
+}
+
+func TestFreshCopies(t *testing.T) {
+	reviewed := time.Now()
+	question := types.Question{
+		ID: 7, Name: "q", URL: "https://leetcode.com/problems/q", Difficulty: types.DifficultyHard, Tags: []string{"graphs"},
+		Section: "Graphs", Companies: []string{"Amazon"}, Lists: []string{"Blind 75"}, Platform: types.PlatformLeetCode, Premium: true,
+		Attempted: true, Mastered: true, AttemptCount: 3, LastReviewed: &reviewed, ReviewInterval: 6,
+	}
+
+	want := types.Question{
+		Name: "q", URL: "https://leetcode.com/problems/q", Difficulty: types.DifficultyHard, Tags: []string{"graphs"},
+		Section: "Graphs", Companies: []string{"Amazon"}, Lists: []string{"Blind 75"}, Platform: types.PlatformLeetCode, Premium: true,
+		EasinessFactor: 2.5,
+	}
+	if got := freshCopies([]types.Question{question}); !reflect.DeepEqual(got, []types.Question{want}) {
+		t.Errorf("freshCopies() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRemapDependencies(t *testing.T) {
+	questions := []types.Question{{ID: 4}, {ID: 9}, {ID: 12}}
+	copies := []types.Question{{ID: 1}, {ID: 2}, {ID: 3}}
+	dependencies := []types.QuestionDependency{
+		{QuestionID: 9, PrerequisiteID: 4},
+		{QuestionID: 12, PrerequisiteID: 9},
+		{QuestionID: 12, PrerequisiteID: 30}, // archived prerequisite, not simulated
+	}
+
+	want := []types.QuestionDependency{{QuestionID: 2, PrerequisiteID: 1}, {QuestionID: 3, PrerequisiteID: 2}}
+	if got := remapDependencies(dependencies, questions, copies); !reflect.DeepEqual(got, want) {
+		t.Errorf("remapDependencies() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadLearnerModel(t *testing.T) {
+	dir := t.TempDir()
+
+	partial := filepath.Join(dir, "partial.json")
+	if err := os.WriteFile(partial, []byte(`{"stability_days": 5, "practice_chance": 0.8}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	model, err := LoadLearnerModel(partial)
+	if err != nil {
+		t.Fatalf("LoadLearnerModel() unexpected error: %v", err)
+	}
+	if model.StabilityDays != 5 || model.PracticeChance != 0.8 || model.Recall != DefaultLearnerModel().Recall {
+		t.Errorf("Expected the file to override only the given fields, got %+v", model)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"recall": 1.5}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadLearnerModel(invalid); err == nil {
+		t.Errorf("Expected an error for an out of range recall")
+	}
+}
@@ -0,0 +1,57 @@
+package types
+
+import "time"
+
+// Attempt records the feedback given each time a question is completed
+type Attempt struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	QuestionID  uint      `json:"question_id" gorm:"index"`
+	Date        string    `json:"date" gorm:"index"` // day the attempt was made, formatted as 2006-01-02
+	CompletedAt time.Time `json:"completed_at"`
+	TimeTaken   int       `json:"time_taken"` // minutes, -1 if unsolved
+	HintsUsed   int       `json:"hints_used"`
+	Optimality  int       `json:"optimality"`
+	Bugs        int       `json:"bugs"`
+	PScore      float64   `json:"p_score"`
+	SessionID   *uint     `json:"session_id,omitempty" gorm:"index"` // mock interview session the attempt was part of
+	// Confidence is how sure the user felt about the solution from 1 to 5, 0 if not reported
+	Confidence      int    `json:"confidence,omitempty"`
+	SawSolution     bool   `json:"saw_solution,omitempty"`     // looked at the solution during the attempt
+	FailureCategory string `json:"failure_category,omitempty"` // what went wrong, one of FailureCategories
+	Approach        string `json:"approach,omitempty"`         // approach used, e.g. "two pointers"
+}
+
+// Failure categories, what went wrong during an attempt
+const (
+	FailurePattern        = "pattern"
+	FailureEdgeCases      = "edge-cases"
+	FailureComplexity     = "complexity"
+	FailureImplementation = "implementation"
+)
+
+// FailureCategories lists the failure categories an attempt can report
+var FailureCategories = []string{FailurePattern, FailureEdgeCases, FailureComplexity, FailureImplementation}
+
+// FailureDescriptions describes each failure category
+var FailureDescriptions = map[string]string{
+	FailurePattern:        "didn't recognise the pattern",
+	FailureEdgeCases:      "missed edge cases",
+	FailureComplexity:     "couldn't reach the required complexity",
+	FailureImplementation: "struggled with the implementation",
+}
+
+// IsValidFailureCategory reports whether category is one of FailureCategories
+func IsValidFailureCategory(category string) bool {
+	for _, c := range FailureCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// AttemptWithQuestion represents an attempt along with the question it was made on
+type AttemptWithQuestion struct {
+	Attempt  Attempt  `json:"attempt"`
+	Question Question `json:"question"`
+}
@@ -0,0 +1,9 @@
+package types
+
+// Goal is an upcoming interview the schedule is compressed towards
+type Goal struct {
+	ID            uint     `json:"id" gorm:"primaryKey"`
+	InterviewDate string   `json:"interview_date"`
+	Focus         []string `json:"focus" gorm:"serializer:json"` // tags to prioritize
+	SetDate       string   `json:"set_date"`
+}
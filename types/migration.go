@@ -0,0 +1,9 @@
+package types
+
+import "time"
+
+// Migration records a one-off data migration that was applied to the database, so it only runs once
+type Migration struct {
+	Name      string    `json:"name" gorm:"primaryKey"`
+	AppliedAt time.Time `json:"applied_at"`
+}
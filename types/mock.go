@@ -0,0 +1,14 @@
+package types
+
+import "time"
+
+// MockSession is a timed mock interview, its attempts point back to it
+type MockSession struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	Date            string    `json:"date"`
+	StartedAt       time.Time `json:"started_at"`
+	EndedAt         time.Time `json:"ended_at"`
+	DurationMinutes int       `json:"duration_minutes"` // time allowed for the whole session
+	Difficulties    []string  `json:"difficulties" gorm:"serializer:json"`
+	Score           float64   `json:"score"` // average p-score of the problems, unsolved ones included
+}
@@ -0,0 +1,9 @@
+package types
+
+// Pause is a vacation during which no questions are planned. Due dates are shifted by
+// the length of the pause when it starts so reviews don't pile up while away.
+type Pause struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	StartDate string `json:"start_date"`
+	Until     string `json:"until"` // first practice day after the pause
+}
@@ -1,17 +1,94 @@
 package types
 
-import "time"
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+)
+
+// Difficulties lists the difficulty tiers in progression order
+var Difficulties = []string{DifficultyEasy, DifficultyMedium, DifficultyHard}
+
+func IsValidDifficulty(difficulty string) bool {
+	for _, d := range Difficulties {
+		if d == difficulty {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	PlatformLeetCode   = "leetcode"
+	PlatformNeetCode   = "neetcode"
+	PlatformHackerRank = "hackerrank"
+	PlatformCodeforces = "codeforces"
+	PlatformCustom     = "custom"
+)
+
+// Platforms lists the sites a question can be hosted on, custom being anything else
+var Platforms = []string{PlatformLeetCode, PlatformNeetCode, PlatformHackerRank, PlatformCodeforces, PlatformCustom}
+
+// platformHosts maps the host names of the known platforms, without "www.", to the platform
+var platformHosts = map[string]string{
+	"leetcode.com":   PlatformLeetCode,
+	"leetcode.cn":    PlatformLeetCode,
+	"neetcode.io":    PlatformNeetCode,
+	"hackerrank.com": PlatformHackerRank,
+	"codeforces.com": PlatformCodeforces,
+}
+
+func IsValidPlatform(platform string) bool {
+	for _, p := range Platforms {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
+
+// PlatformFromURL returns the platform a question URL points to, custom when it isn't a known one
+func PlatformFromURL(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return PlatformCustom
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if platform, ok := platformHosts[host]; ok {
+		return platform
+	}
+	return PlatformCustom
+}
+
+// LeechTag is added to the tags of a question that keeps failing its recalls
+const LeechTag = "leech"
 
 type Question struct {
 	ID           uint       `json:"id" gorm:"primaryKey"`
 	Name         string     `json:"name"`
 	URL          string     `json:"url"`
 	Difficulty   string     `json:"difficulty"`
+	Tags         []string   `json:"tags" gorm:"serializer:json"`
+	Section      string     `json:"section,omitempty"`                          // section of the problem list it comes from, e.g. "Graphs"
+	Companies    []string   `json:"companies,omitempty" gorm:"serializer:json"` // companies known to ask it, e.g. "Amazon"
+	Lists        []string   `json:"lists,omitempty" gorm:"serializer:json"`     // curated lists it belongs to, e.g. "Blind 75"
+	Platform     string     `json:"platform,omitempty"`                         // site hosting it, see Platforms; derived from the URL when empty
+	Premium      bool       `json:"premium" gorm:"default:false"`               // locked behind a paid subscription
+	Notes        string     `json:"notes"`
 	LastReviewed *time.Time `json:"last_reviewed"`
 	Attempted    bool       `json:"attempted"`
+	Archived     bool       `json:"archived" gorm:"default:false"`  // excluded from scheduling, history is kept
+	Suspended    bool       `json:"suspended" gorm:"default:false"` // a leech set aside until it is resumed
 
 	// Spaced Repetition Algorithm fields
 	ReviewInterval int     `json:"review_interval" gorm:"default:0"`   // days until next review
+	DueShift       int     `json:"due_shift" gorm:"default:0"`         // days pauses pushed the next review back by
 	EasinessFactor float64 `json:"easiness_factor" gorm:"default:2.5"` // interval growth factor
 	ReviewStreak   int     `json:"review_streak" gorm:"default:0"`     // consecutive successful recalls
 	Mastered       bool    `json:"mastered" gorm:"default:false"`      // progression gate flag
@@ -19,15 +96,123 @@ type Question struct {
 	LastPScore     float64 `json:"last_p_score" gorm:"default:0"`      // previous attempt's p-score
 }
 
+// DueDate returns when the question comes due: its review interval after the last review, pushed
+// back by pauses. ok is false if it was never reviewed.
+func (q Question) DueDate() (due time.Time, ok bool) {
+	if q.LastReviewed == nil {
+		return time.Time{}, false
+	}
+	return q.LastReviewed.AddDate(0, 0, q.ReviewInterval+q.DueShift), true
+}
+
+// HasTag reports whether the question is tagged with the given tag, ignoring case
+func (q Question) HasTag(tag string) bool {
+	return containsFold(q.Tags, tag)
+}
+
+// AskedBy reports whether the question is known to be asked by the given company, ignoring case
+func (q Question) AskedBy(company string) bool {
+	return containsFold(q.Companies, company)
+}
+
+// InList reports whether the question belongs to the given list, ignoring case
+func (q Question) InList(list string) bool {
+	return containsFold(q.Lists, list)
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Slug returns the part of the question URL's path naming the problem, which names its statement
+// file when importing: the one after "problems" if there is one, e.g. "two-sum" for
+// https://leetcode.com/problems/two-sum/description, the last one otherwise
+func (q Question) Slug() string {
+	u, err := url.Parse(strings.TrimSpace(q.URL))
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i, part := range parts[:len(parts)-1] {
+		if part == "problems" {
+			return parts[i+1]
+		}
+	}
+	return parts[len(parts)-1]
+}
+
+// QuestionContent is the problem statement of a question stored for practicing offline, as markdown
+type QuestionContent struct {
+	QuestionID  uint   `json:"question_id" gorm:"primaryKey;autoIncrement:false"`
+	Statement   string `json:"statement"`
+	Examples    string `json:"examples,omitempty"`
+	Constraints string `json:"constraints,omitempty"`
+}
+
+// Markdown returns the statement as a single markdown document with a heading per part
+func (c QuestionContent) Markdown() string {
+	parts := []string{strings.TrimSpace(c.Statement)}
+	if examples := strings.TrimSpace(c.Examples); examples != "" {
+		parts = append(parts, "## Examples\n\n"+examples)
+	}
+	if constraints := strings.TrimSpace(c.Constraints); constraints != "" {
+		parts = append(parts, "## Constraints\n\n"+constraints)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// QuestionDependency makes a question a prerequisite of another: the dependent question is
+// only served as a new question once the prerequisite was attempted
+type QuestionDependency struct {
+	QuestionID     uint `json:"question_id" gorm:"primaryKey;autoIncrement:false"`     // the dependent question
+	PrerequisiteID uint `json:"prerequisite_id" gorm:"primaryKey;autoIncrement:false"` // the question to attempt first
+}
+
 type TodayQuestion struct {
-	ID         uint   `json:"id" gorm:"primaryKey"`
-	QuestionID uint   `json:"question_id"`
-	Date       string `json:"date"`
-	Completed  bool   `json:"completed" gorm:"default:false"`
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	QuestionID  uint   `json:"question_id"`
+	Date        string `json:"date"`
+	Completed   bool   `json:"completed" gorm:"default:false"`
+	CarriedFrom string `json:"carried_from,omitempty"`                // date of the missed plan this entry was rolled over from
+	CarriedOver bool   `json:"carried_over" gorm:"default:false"`     // rolled into a later plan, no longer in the backlog
+	Dismissed   bool   `json:"dismissed" gorm:"default:false"`        // cleared from the backlog without completing it
+	Pick        *Pick  `json:"pick,omitempty" gorm:"serializer:json"` // why the planner picked the question
+}
+
+// Kinds of planner picks
+const (
+	PickNew    = "new"    // a question that was never attempted
+	PickReview = "review" // an attempted question picked for review
+)
+
+// Pick records why the planner put a question in a plan
+type Pick struct {
+	Phase   string   `json:"phase"`
+	Kind    string   `json:"kind"`
+	Pool    int      `json:"pool"`            // number of candidates it was picked among
+	Score   float64  `json:"score,omitempty"` // review priority or p-score it was ranked by
+	Reasons []string `json:"reasons,omitempty"`
+	Seed    int64    `json:"seed,omitempty"` // seed of the random source used for the plan
+}
+
+// String describes the pick, e.g. "review: 4 day(s) overdue, low p-score 0.42"
+func (p Pick) String() string {
+	if len(p.Reasons) == 0 {
+		return p.Kind
+	}
+	return p.Kind + ": " + strings.Join(p.Reasons, ", ")
 }
 
 // TodayQuestionWithStatus represents a question for today with its completion status
 type TodayQuestionWithStatus struct {
-	Question  Question `json:"question"`
-	Completed bool     `json:"completed"`
+	Question    Question `json:"question"`
+	Date        string   `json:"date"` // practice day of the plan the question was assigned in
+	Completed   bool     `json:"completed"`
+	CarriedFrom string   `json:"carried_from,omitempty"`
+	Pick        *Pick    `json:"pick,omitempty"`
 }